@@ -0,0 +1,431 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gandarez/semver-action/cmd/generate"
+	"github.com/gandarez/semver-action/pkg/actions"
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/strategy"
+)
+
+type (
+	// command is a CLI subcommand.
+	command struct {
+		usage string
+		run   func(params generate.Params, output string, stdout io.Writer) error
+	}
+
+	// input is an action input exposed as a flag.
+	input struct {
+		name   string
+		usage  string
+		isBool bool
+	}
+
+	// inputValue holds a flag value and whether it was set.
+	inputValue struct {
+		value  string
+		set    bool
+		isBool bool
+	}
+)
+
+// nolint: gochecknoglobals
+var commands = map[string]command{
+	"next": {
+		usage: "Calculate the next semantic version",
+		run:   next,
+	},
+	"current": {
+		usage: "Print the latest semantic version tag",
+		run:   current,
+	},
+	"explain": {
+		usage: "Print how the next semantic version is decided without creating anything",
+		run:   explain,
+	},
+}
+
+// inputs maps 1:1 to the action inputs. Flag names use dashes instead of underscores.
+// nolint: gochecknoglobals
+var inputs = []input{
+	{name: "commit_sha", usage: "commit sha to calculate the version for. Falls back to GITHUB_SHA or HEAD"},
+	{name: "repo_dir", usage: "repository directory"},
+	{name: "config_file", usage: "config file relative to repo dir. Defaults to .semver.yml, .semver.yaml or .semver.json"},
+	{name: "bump", usage: "bump strategy: auto, major, minor, patch or graduate"},
+	{name: "initial_development", usage: "downgrade major to minor and minor to patch while major is zero", isBool: true},
+	{name: "bump_source", usage: "where the bump level is taken from: branch or commits"},
+	{name: "branching_model", usage: "branching model: git-flow, trunk-based, github-flow or calver"},
+	{name: "merge_message_format", usage: "merge commit message format"},
+	{name: "base_version", usage: "version to use as base for the generation, skips version bumps"},
+	{name: "prefix", usage: "version prefix"},
+	{name: "prerelease_id", usage: "prerelease identifier"},
+	{name: "prerelease_channels", usage: "JSON list of dest branch patterns and their prerelease ids"},
+	{name: "main_branch_name", usage: "main branch name"},
+	{name: "develop_branch_name", usage: "develop branch name"},
+	{name: "patch_regex", usage: "regex to match patch branches"},
+	{name: "minor_regex", usage: "regex to match minor branches"},
+	{name: "major_regex", usage: "regex to match major branches"},
+	{name: "build_regex", usage: "regex to match build branches"},
+	{name: "hotfix_regex", usage: "regex to match hotfix branches"},
+	{name: "exclude_regex", usage: "regex to exclude branches from bumping"},
+	{name: "maintenance_regex", usage: "regex to match maintenance branches in trunk-based model"},
+	{name: "rules", usage: "JSON list of bump rules evaluated before the branch patterns"},
+	{name: "labels", usage: "comma separated pull request labels"},
+	{name: "label_bumps", usage: "JSON object mapping pull request labels to major, minor, patch or skip"},
+	{name: "calver_format", usage: "version format in calver model"},
+	{name: "strategy_options", usage: "JSON object of options passed to a registered strategy"},
+	{name: "include_tag_pattern", usage: "glob of tags to consider"},
+	{name: "exclude_tag_pattern", usage: "glob of tags to ignore"},
+	{name: "components", usage: "JSON list of monorepo components"},
+	{name: "version_files", usage: "JSON list of files to write the calculated version into"},
+	{name: "go_module", usage: "check go.mod module path on major bumps: off, warn or error"},
+	{name: "go_module_dir", usage: "dir of a nested go module, prefixing its tags"},
+	{name: "api_diff", usage: "compare the exported go api to the latest tag: off, bump or verify"},
+	{name: "include_paths", usage: "comma separated path globs of the files relevant to the bump"},
+	{name: "exclude_paths", usage: "comma separated path globs of the files not relevant to the bump"},
+	{name: "unmatched_paths", usage: "bump when no relevant file changed: skip or build"},
+	{name: "docker_tags", usage: "comma separated docker tags to render: version, minor, major, latest, edge or sha"},
+	{name: "docker_image", usage: "image to prepend to the docker tags"},
+	{name: "docker_tags_separator", usage: "docker tags separator: newline or comma"},
+	{name: "create_tag", usage: "create the calculated tag", isBool: true},
+	{name: "push_tag", usage: "push the created tag", isBool: true},
+	{name: "tag_message", usage: "annotated tag message template"},
+	{name: "remote", usage: "remote to push the tag to"},
+	{name: "changelog", usage: "generate the changelog", isBool: true},
+	{name: "changelog_file", usage: "file to prepend the changelog to"},
+	{name: "event_name", usage: "event name. Falls back to GITHUB_EVENT_NAME"},
+	{name: "event_path", usage: "event payload file. Falls back to GITHUB_EVENT_PATH"},
+	{name: "ref", usage: "ref being built. Falls back to GITHUB_REF"},
+	{name: "head_ref", usage: "pull request source branch. Falls back to GITHUB_HEAD_REF"},
+	{name: "base_ref", usage: "pull request dest branch. Falls back to GITHUB_BASE_REF"},
+	{name: "explain", usage: "do not write files nor create tags", isBool: true},
+	{name: "debug", usage: "enable debug logs", isBool: true},
+}
+
+// nolint: gochecknoglobals
+var (
+	helpArgs     = []string{"help", "-h", "--help"}
+	validOutputs = []string{"text", "json"}
+)
+
+// String implements flag.Value.
+func (v *inputValue) String() string {
+	if v == nil {
+		return ""
+	}
+
+	return v.value
+}
+
+// Set implements flag.Value.
+func (v *inputValue) Set(value string) error {
+	v.value = value
+	v.set = true
+
+	return nil
+}
+
+// IsBoolFlag allows boolean inputs to be passed without a value.
+func (v *inputValue) IsBoolFlag() bool {
+	return v.isBool
+}
+
+// IsCommand returns true if name is a CLI subcommand.
+func IsCommand(name string) bool {
+	if stringInSlice(name, helpArgs) {
+		return true
+	}
+
+	_, ok := commands[name]
+
+	return ok
+}
+
+// Run runs the subcommand in args[0] with the remaining args as flags. It
+// returns the process exit code.
+func Run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		usage(stderr)
+		return 2
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		usage(stderr)
+
+		if stringInSlice(args[0], helpArgs) {
+			return 0
+		}
+
+		return 2
+	}
+
+	fs := flag.NewFlagSet("semver "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	values := make(map[string]*inputValue, len(inputs))
+
+	for _, in := range inputs {
+		value := &inputValue{isBool: in.isBool}
+		values[in.name] = value
+
+		fs.Var(value, strings.ReplaceAll(in.name, "_", "-"), in.usage)
+	}
+
+	output := fs.String("output", "text", "output format: text or json")
+
+	if err := fs.Parse(args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+
+		return 2
+	}
+
+	if !stringInSlice(*output, validOutputs) {
+		_, _ = fmt.Fprintf(stderr, "invalid output value: %s\n", *output)
+		return 2
+	}
+
+	params, err := loadParams(values)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "failed to load parameters: %s\n", err)
+		return 1
+	}
+
+	if err := cmd.run(params, *output, stdout); err != nil {
+		_, _ = fmt.Fprintf(stderr, "%s\n", err)
+		return 1
+	}
+
+	return 0
+}
+
+// loadParams loads params from the flags falling back to the action inputs.
+// Commit sha defaults to HEAD when neither the flag nor GITHUB_SHA is set.
+func loadParams(values map[string]*inputValue) (generate.Params, error) {
+	lookup := func(name string) string {
+		if value, ok := values[name]; ok && value.set {
+			return strings.TrimSpace(value.value)
+		}
+
+		return actions.GetInput(name)
+	}
+
+	if lookup("commit_sha") == "" && os.Getenv("GITHUB_SHA") == "" {
+		repoDir := lookup("repo_dir")
+		if repoDir == "" {
+			repoDir = "."
+		}
+
+		gc := git.New(repoDir)
+
+		head, err := gc.Clean(gc.GitCmd(nil, "-C", repoDir, "rev-parse", "HEAD"))
+		if err != nil {
+			return generate.Params{}, fmt.Errorf("failed to resolve HEAD: %s", err)
+		}
+
+		values["commit_sha"] = &inputValue{value: head, set: true}
+	}
+
+	return generate.LoadParamsWith(lookup)
+}
+
+func next(params generate.Params, output string, stdout io.Writer) error {
+	result, err := generate.RunWithParams(params)
+	if err != nil {
+		return fmt.Errorf("failed to generate semver version: %s", err)
+	}
+
+	if output == "json" {
+		return printJSON(stdout, result)
+	}
+
+	if result.SemverTag != "" {
+		_, _ = fmt.Fprintln(stdout, result.SemverTag)
+	}
+
+	for _, name := range sortedKeys(result.Components) {
+		_, _ = fmt.Fprintf(stdout, "%s %s\n", name, result.Components[name].SemverTag)
+	}
+
+	return nil
+}
+
+func current(params generate.Params, output string, stdout io.Writer) error {
+	gc := git.New(params.RepoDir)
+
+	if !gc.IsRepo() {
+		return fmt.Errorf("current folder is not a git repository")
+	}
+
+	branchingStrategy, err := generate.NewStrategy(params)
+	if err != nil {
+		return fmt.Errorf("failed to decide branching strategy: %s", err)
+	}
+
+	features := strategy.FeaturesOf(branchingStrategy)
+
+	if len(params.Components) == 0 {
+		tag := gc.LatestTag(params.IncludeTagPattern, generate.ExcludeTagPattern(params, features))
+
+		if output == "json" {
+			return printJSON(stdout, map[string]string{"current_tag": tag})
+		}
+
+		if tag != "" {
+			_, _ = fmt.Fprintln(stdout, tag)
+		}
+
+		return nil
+	}
+
+	tags := make(map[string]string, len(params.Components))
+
+	for _, component := range params.Components {
+		componentParams := params
+		componentParams.Prefix = component.Prefix
+
+		tags[component.Name] = gc.LatestTag(component.Prefix+"[0-9]*", generate.ExcludeTagPattern(componentParams, features))
+	}
+
+	if output == "json" {
+		return printJSON(stdout, map[string]map[string]string{"components": tags})
+	}
+
+	for _, name := range sortedKeys(tags) {
+		_, _ = fmt.Fprintf(stdout, "%s %s\n", name, tags[name])
+	}
+
+	return nil
+}
+
+// explain calculates the next semantic version without creating anything and
+// prints how it was decided.
+func explain(params generate.Params, output string, stdout io.Writer) error {
+	params.Explain = true
+
+	result, err := generate.RunWithParams(params)
+	if err != nil {
+		return fmt.Errorf("failed to generate semver version: %s", err)
+	}
+
+	if output == "json" {
+		return printJSON(stdout, result.Trace)
+	}
+
+	_, err = fmt.Fprint(stdout, result.Trace.Text())
+
+	return err
+}
+
+// PrintText prints the result as key=value lines.
+func PrintText(w io.Writer, result generate.Result) error {
+	lines := [][2]string{
+		{"previous_tag", result.PreviousTag},
+		{"ancestor_tag", result.AncestorTag},
+		{"semver_tag", result.SemverTag},
+		{"is_prerelease", strconv.FormatBool(result.IsPrerelease)},
+		{"version", result.Version},
+		{"major", strconv.FormatUint(result.Major, 10)},
+		{"minor", strconv.FormatUint(result.Minor, 10)},
+		{"patch", strconv.FormatUint(result.Patch, 10)},
+		{"prerelease", result.Prerelease},
+		{"build", result.Build},
+		{"bump", result.Bump},
+		{"tag_created", strconv.FormatBool(result.TagCreated)},
+		{"tag_pushed", strconv.FormatBool(result.TagPushed)},
+	}
+
+	for _, line := range lines {
+		if _, err := fmt.Fprintf(w, "%s=%s\n", line[0], line[1]); err != nil {
+			return err
+		}
+	}
+
+	if result.VersionFiles != nil {
+		if _, err := fmt.Fprintf(w, "version_files=%s\n", strings.Join(result.VersionFiles, ",")); err != nil {
+			return err
+		}
+	}
+
+	if result.DockerTags != "" {
+		// tags never contain commas, so they're kept in a single line
+		tags := strings.ReplaceAll(result.DockerTags, "\n", ",")
+
+		if _, err := fmt.Fprintf(w, "docker_tags=%s\n", tags); err != nil {
+			return err
+		}
+	}
+
+	if result.SkippedTags != nil {
+		if _, err := fmt.Fprintf(w, "skipped_tags=%s\n", strings.Join(result.SkippedTags, ",")); err != nil {
+			return err
+		}
+	}
+
+	for _, name := range sortedKeys(result.Components) {
+		if _, err := fmt.Fprintf(w, "%s.semver_tag=%s\n", name, result.Components[name].SemverTag); err != nil {
+			return err
+		}
+	}
+
+	if result.Changelog != "" {
+		if _, err := fmt.Fprintf(w, "\n%s", result.Changelog); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func printJSON(w io.Writer, v any) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("failed to encode output: %s", err)
+	}
+
+	return nil
+}
+
+func usage(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "Usage: semver <command> [flags]\n\nCommands:\n")
+
+	for _, name := range sortedKeys(commands) {
+		_, _ = fmt.Fprintf(w, "  %-8s %s\n", name, commands[name].usage)
+	}
+
+	_, _ = fmt.Fprintf(w, "\nRun 'semver <command> -h' to list the flags.\n")
+}
+
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func stringInSlice(a string, list []string) bool {
+	for _, b := range list {
+		if b == a {
+			return true
+		}
+	}
+
+	return false
+}
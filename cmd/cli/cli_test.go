@@ -0,0 +1,277 @@
+package cli_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gandarez/semver-action/cmd/cli"
+
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsCommand(t *testing.T) {
+	tests := map[string]struct {
+		Name     string
+		Expected bool
+	}{
+		"next": {
+			Name:     "next",
+			Expected: true,
+		},
+		"current": {
+			Name:     "current",
+			Expected: true,
+		},
+		"explain": {
+			Name:     "explain",
+			Expected: true,
+		},
+		"help": {
+			Name:     "--help",
+			Expected: true,
+		},
+		"action input": {
+			Name:     "auto",
+			Expected: false,
+		},
+		"empty action input": {
+			Name:     "",
+			Expected: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, cli.IsCommand(test.Name))
+		})
+	}
+}
+
+func TestRun_Next(t *testing.T) {
+	repoDir := setupRepo(t)
+
+	var stdout, stderr bytes.Buffer
+
+	code := cli.Run([]string{
+		"next",
+		"--repo-dir", repoDir,
+		"--branching-model", "trunk-based",
+		"--main-branch-name", "master",
+	}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	assert.Equal(t, "v1.1.0\n", stdout.String())
+}
+
+func TestRun_Next_JSON(t *testing.T) {
+	repoDir := setupRepo(t)
+
+	var stdout, stderr bytes.Buffer
+
+	code := cli.Run([]string{
+		"next",
+		"--repo-dir", repoDir,
+		"--branching-model", "trunk-based",
+		"--main-branch-name", "master",
+		"--output", "json",
+	}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	var result map[string]any
+
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+
+	assert.Equal(t, "v1.0.0", result["previous_tag"])
+	assert.Equal(t, "v1.1.0", result["semver_tag"])
+	assert.Equal(t, false, result["is_prerelease"])
+	assert.Equal(t, "1.1.0", result["version"])
+	assert.Equal(t, float64(1), result["minor"])
+	assert.Equal(t, "minor", result["bump"])
+	assert.Equal(t, true, result["is_minor"])
+}
+
+func TestRun_Next_EnvFallback(t *testing.T) {
+	repoDir := setupRepo(t)
+
+	t.Setenv("INPUT_BRANCHING_MODEL", "trunk-based")
+	t.Setenv("INPUT_MAIN_BRANCH_NAME", "master")
+	t.Setenv("INPUT_BUMP", "major")
+
+	var stdout, stderr bytes.Buffer
+
+	code := cli.Run([]string{"next", "--repo-dir", repoDir}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	assert.Equal(t, "v2.0.0\n", stdout.String())
+}
+
+func TestRun_Current(t *testing.T) {
+	repoDir := setupRepo(t)
+
+	var stdout, stderr bytes.Buffer
+
+	code := cli.Run([]string{"current", "--repo-dir", repoDir}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	assert.Equal(t, "v1.0.0\n", stdout.String())
+}
+
+func TestRun_Current_GitHubFlow(t *testing.T) {
+	repoDir := setupRepo(t)
+
+	runGit(t, repoDir, "tag", "v1.1.0-pr.2.1")
+
+	var stdout, stderr bytes.Buffer
+
+	code := cli.Run([]string{"current", "--repo-dir", repoDir, "--branching-model", "github-flow"}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	// pull request previews are not releases
+	assert.Equal(t, "v1.0.0\n", stdout.String())
+}
+
+func TestRun_Explain(t *testing.T) {
+	repoDir := setupRepo(t)
+
+	var stdout, stderr bytes.Buffer
+
+	code := cli.Run([]string{
+		"explain",
+		"--repo-dir", repoDir,
+		"--branching-model", "trunk-based",
+		"--main-branch-name", "master",
+		"--create-tag",
+	}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	assert.Contains(t, stdout.String(), `branches: "feature/some" -> "master" (from current branch and commit message)`)
+	assert.Contains(t, stdout.String(), "decision: source branch matches minor pattern")
+	assert.Contains(t, stdout.String(), "increment minor: 1.0.0 -> 1.1.0")
+	assert.Contains(t, stdout.String(), "semver tag: v1.1.0")
+	assert.Contains(t, stdout.String(), "note: dry run: tags not created")
+
+	// explain never creates tags
+	assert.Equal(t, "v1.0.0", runGit(t, repoDir, "tag", "--list"))
+}
+
+func TestRun_Explain_NotLogged(t *testing.T) {
+	repoDir := setupRepo(t)
+
+	handler := memory.New()
+
+	logger := log.Log.(*log.Logger)
+	previous := logger.Handler
+
+	logger.Handler = handler
+	defer func() { logger.Handler = previous }()
+
+	var stdout, stderr bytes.Buffer
+
+	code := cli.Run([]string{
+		"explain",
+		"--repo-dir", repoDir,
+		"--branching-model", "trunk-based",
+		"--main-branch-name", "master",
+	}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	// the trace is printed to stdout only
+	for _, entry := range handler.Entries {
+		assert.NotContains(t, entry.Message, "decision: source branch matches minor pattern")
+	}
+
+	assert.Contains(t, stdout.String(), "decision: source branch matches minor pattern")
+}
+
+func TestRun_Explain_JSON(t *testing.T) {
+	repoDir := setupRepo(t)
+
+	var stdout, stderr bytes.Buffer
+
+	code := cli.Run([]string{
+		"explain",
+		"--repo-dir", repoDir,
+		"--branching-model", "trunk-based",
+		"--main-branch-name", "master",
+		"--output", "json",
+	}, &stdout, &stderr)
+	require.Equal(t, 0, code, stderr.String())
+
+	var result map[string]any
+
+	require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+
+	assert.Equal(t, "feature/some", result["source_branch"])
+	assert.Equal(t, "minor", result["method"])
+	assert.Equal(t, "v1.0.0", result["latest_tag"])
+	assert.Equal(t, "v1.1.0", result["semver_tag"])
+}
+
+func TestRun_InvalidOutput(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := cli.Run([]string{"next", "--output", "yaml"}, &stdout, &stderr)
+
+	assert.Equal(t, 2, code)
+	assert.Equal(t, "invalid output value: yaml\n", stderr.String())
+}
+
+func TestRun_InvalidParams(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := cli.Run([]string{"next", "--commit-sha", "2f1a5a6", "--bump", "invalid"}, &stdout, &stderr)
+
+	assert.Equal(t, 1, code)
+	assert.Equal(t, "failed to load parameters: invalid bump value: invalid\n", stderr.String())
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+
+	code := cli.Run([]string{"unknown"}, &stdout, &stderr)
+
+	assert.Equal(t, 2, code)
+	assert.Contains(t, stderr.String(), "Usage: semver <command> [flags]")
+}
+
+// setupRepo creates a repository in master with tag v1.0.0 followed by a merged feature branch.
+func setupRepo(t *testing.T) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	tmp := t.TempDir()
+	repoDir := filepath.Join(tmp, "repo")
+
+	// keep safe.directory changes out of the user global config
+	t.Setenv("GIT_CONFIG_GLOBAL", filepath.Join(tmp, "gitconfig"))
+
+	for _, env := range []string{"GITHUB_SHA", "GITHUB_EVENT_NAME", "GITHUB_EVENT_PATH", "GITHUB_REF", "GITHUB_HEAD_REF", "GITHUB_BASE_REF"} {
+		t.Setenv(env, "")
+	}
+
+	runGit(t, tmp, "init", "--initial-branch", "master", repoDir)
+	runGit(t, repoDir, "-c", "user.name=test", "-c", "user.email=test@example.com",
+		"commit", "--allow-empty", "-m", "initial commit")
+	runGit(t, repoDir, "tag", "v1.0.0")
+	runGit(t, repoDir, "-c", "user.name=test", "-c", "user.email=test@example.com",
+		"commit", "--allow-empty", "-m", "Merge pull request #2 from gandarez/feature/some\n\nsome feature")
+
+	return repoDir
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...) // nolint:gosec
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	return strings.TrimSpace(string(out))
+}
@@ -0,0 +1,130 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gandarez/semver-action/internal/apidiff"
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/strategy"
+	"github.com/gandarez/semver-action/pkg/trace"
+
+	"github.com/apex/log"
+)
+
+// nolint: gochecknoglobals
+var (
+	validAPIDiffModes = []string{"off", "bump", "verify"}
+	// partLevels orders the version parts by how much they bump.
+	partLevels = map[string]int{"build": 0, "patch": 1, "minor": 2, "major": 3}
+)
+
+// apiDiff compares the exported Go API at the latest tag and at the commit, and
+// returns the method and version required by the changes. In bump mode a
+// smaller bump is raised to the required one, and in verify mode it fails.
+// Bumps are never lowered, as they may be required by behavior changes.
+func apiDiff(params Params, features strategy.Features, gc git.Git, latestTag, bump, method, version string, tr *trace.Trace) (string, string, error) {
+	if params.APIDiff == "" || params.APIDiff == "off" || (params.APIDiff == "bump" && bump != "auto") {
+		return method, version, nil
+	}
+
+	decided := bumpedPartOf(features, method, version)
+
+	decidedLevel, ok := partLevels[decided]
+
+	// no bump is smaller than any bump, so verify mode fails on api changes
+	if decided == "" && params.APIDiff == "verify" {
+		decidedLevel, ok = -1, true
+	}
+
+	// final releases and calendar versions are not compared
+	if !ok || latestTag == "" || features.Calendar {
+		return method, version, nil
+	}
+
+	report, err := compareAPI(params, gc, latestTag)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to diff api: %s", err)
+	}
+
+	for _, change := range report.Changes {
+		if change.Compatible {
+			log.Infof("compatible api change: %s", change)
+		} else {
+			log.Infof("incompatible api change: %s", change)
+		}
+	}
+
+	level := report.Level()
+
+	tr.Notef("api diff against %s found %s changes", latestTag, level)
+
+	required := level.Bump()
+	if required == "" || partLevels[required] <= decidedLevel {
+		return method, version, nil
+	}
+
+	if params.APIDiff == "verify" && decided == "" {
+		return "", "", fmt.Errorf("%s api changes require a %s bump, but there is no bump", level, required)
+	}
+
+	if params.APIDiff == "verify" {
+		return "", "", fmt.Errorf("%s api changes require a %s bump, but the bump is %s", level, required, decided)
+	}
+
+	tr.Notef("api diff raised the bump from %s to %s", decided, required)
+
+	if features.BuildVersions && method == "build" {
+		return method, required, nil
+	}
+
+	return required, "", nil
+}
+
+// bumpedPartOf returns the version part bumped by the method and version.
+func bumpedPartOf(features strategy.Features, method, version string) string {
+	switch {
+	case features.BuildVersions && method == "build" && version == "":
+		return "build"
+	case features.BuildVersions && method == "build":
+		return version
+	case method == "hotfix":
+		return "patch"
+	default:
+		return method
+	}
+}
+
+// compareAPI checks out the latest tag and the commit into temporary worktrees
+// and compares the API of the go module dir.
+func compareAPI(params Params, gc git.Git, latestTag string) (apidiff.Report, error) {
+	dir, err := os.MkdirTemp("", "semver-apidiff-")
+	if err != nil {
+		return apidiff.Report{}, fmt.Errorf("failed to create temp dir: %s", err)
+	}
+
+	defer os.RemoveAll(dir) // nolint:errcheck
+
+	previousDir, currentDir := filepath.Join(dir, "previous"), filepath.Join(dir, "current")
+
+	if err := gc.AddWorktree(previousDir, latestTag); err != nil {
+		return apidiff.Report{}, err
+	}
+
+	defer removeWorktree(gc, previousDir)
+
+	if err := gc.AddWorktree(currentDir, params.CommitSha); err != nil {
+		return apidiff.Report{}, err
+	}
+
+	defer removeWorktree(gc, currentDir)
+
+	return apidiff.Compare(filepath.Join(previousDir, params.GoModuleDir), filepath.Join(currentDir, params.GoModuleDir))
+}
+
+func removeWorktree(gc git.Git, dir string) {
+	if err := gc.RemoveWorktree(dir); err != nil {
+		log.Warnf("failed to remove worktree: %s", err)
+	}
+}
@@ -0,0 +1,61 @@
+package generate
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/gandarez/semver-action/internal/changelog"
+	"github.com/gandarez/semver-action/pkg/git"
+
+	"github.com/apex/log"
+)
+
+// Changelog renders the changelog of the commits between the latest tag the
+// version was calculated from and the commit sha, when enabled. It's prepended
+// to the changelog file, if set.
+func Changelog(params Params, gc git.Git, result Result) (Result, error) {
+	if !params.Changelog || result.SemverTag == "" {
+		return result, nil
+	}
+
+	commits, err := gc.MainlineCommits(result.LatestTag, params.CommitSha)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to get commits since latest tag: %s", err)
+	}
+
+	log.Debugf("found %d mainline commits since latest tag %q\n", len(commits), result.LatestTag)
+
+	cl := changelog.New(result.SemverTag, commits, changelog.Config{
+		MergeFormat:   params.MergeFormat,
+		PatchPattern:  params.PatchPattern,
+		MinorPattern:  params.MinorPattern,
+		MajorPattern:  params.MajorPattern,
+		BuildPattern:  params.BuildPattern,
+		HotfixPattern: params.HotfixPattern,
+	})
+
+	result.Changelog = cl.Markdown()
+
+	if params.ChangelogFile == "" {
+		return result, nil
+	}
+
+	if params.Explain {
+		result.Trace.Notef("dry run: changelog not prepended to %s", params.ChangelogFile)
+
+		return result, nil
+	}
+
+	fp := params.ChangelogFile
+	if !filepath.IsAbs(fp) {
+		fp = filepath.Join(params.RepoDir, fp)
+	}
+
+	if err := changelog.Prepend(fp, result.Changelog); err != nil {
+		return Result{}, err
+	}
+
+	log.Infof("changelog prepended to %s", fp)
+
+	return result, nil
+}
@@ -0,0 +1,78 @@
+package generate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gandarez/semver-action/cmd/generate"
+	"github.com/gandarez/semver-action/pkg/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangelog(t *testing.T) {
+	repoDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "CHANGELOG.md"), []byte("# Changelog\n\n## v1.2.3\n\n- Initial\n"), 0600))
+
+	p, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	p.CommitSha = "81918ffc"
+	p.RepoDir = repoDir
+	p.Changelog = true
+	p.ChangelogFile = "CHANGELOG.md"
+
+	// maintenance lines and github-flow previews restrict the latest tag, so the
+	// one the version was calculated from is used instead of looking it up again
+	gc := initGitClientMock(t, "v1.4.0", "", "", "", p.CommitSha)
+	gc.MainlineCommitsFn = func(from, to string) ([]git.Commit, error) {
+		assert.Equal(t, "v1.2.3", from)
+		assert.Equal(t, "81918ffc", to)
+
+		return []git.Commit{
+			{Hash: "81918ffc", Message: "Merge pull request #12 from gandarez/feature/some\n\nAdd some"},
+			{Hash: "e63c125b", Message: "Merge pull request #11 from gandarez/bugfix/other\n\nFix other"},
+		}, nil
+	}
+
+	result, err := generate.Changelog(p, gc, generate.Result{
+		PreviousTag: "v1.2.3",
+		SemverTag:   "v1.2.4",
+		LatestTag:   "v1.2.3",
+	})
+	require.NoError(t, err)
+
+	assert.Zero(t, gc.LatestTagFnInvoked)
+
+	expected := "## v1.2.4\n\n### Features\n\n- Add some (#12)\n\n### Bug Fixes\n\n- Fix other (#11)\n"
+
+	assert.Equal(t, expected, result.Changelog)
+
+	data, err := os.ReadFile(filepath.Join(repoDir, "CHANGELOG.md"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "# Changelog\n\n"+expected+"\n## v1.2.3\n\n- Initial\n", string(data))
+}
+
+func TestChangelog_Disabled(t *testing.T) {
+	gc := &gitClientMock{}
+
+	result, err := generate.Changelog(generate.Params{}, gc, generate.Result{SemverTag: "v1.3.0"})
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Changelog)
+	assert.Zero(t, gc.MainlineCommitsFnInvoked)
+}
+
+func TestChangelog_NoVersionBump(t *testing.T) {
+	gc := &gitClientMock{}
+
+	result, err := generate.Changelog(generate.Params{Changelog: true}, gc, generate.Result{})
+	require.NoError(t, err)
+
+	assert.Empty(t, result.Changelog)
+	assert.Zero(t, gc.MainlineCommitsFnInvoked)
+}
@@ -0,0 +1,69 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/gandarez/semver-action/pkg/regex"
+
+	"github.com/blang/semver/v4"
+)
+
+type (
+	// PrereleaseChannel maps the dest branches matching its pattern to a
+	// prerelease identifier, e.g. release/* to beta.
+	PrereleaseChannel struct {
+		Branch       regex.Regex
+		PrereleaseID string
+	}
+
+	prereleaseChannelConfig struct {
+		Branch       string `json:"branch"`
+		PrereleaseID string `json:"prerelease_id"`
+	}
+)
+
+// parsePrereleaseChannels parses the JSON list of prerelease channels.
+func parsePrereleaseChannels(data string) ([]PrereleaseChannel, error) {
+	var configs []prereleaseChannelConfig
+
+	if err := json.Unmarshal([]byte(data), &configs); err != nil {
+		return nil, err
+	}
+
+	channels := make([]PrereleaseChannel, 0, len(configs))
+
+	for i, config := range configs {
+		if config.Branch == "" {
+			return nil, fmt.Errorf("prerelease channel at index %d has no branch", i)
+		}
+
+		compiled, err := regex.Compile(config.Branch)
+		if err != nil {
+			return nil, fmt.Errorf("prerelease channel %q has invalid branch: %s", config.Branch, err)
+		}
+
+		if _, err := semver.NewPRVersion(config.PrereleaseID); err != nil || config.PrereleaseID == "" {
+			return nil, fmt.Errorf("prerelease channel %q has invalid prerelease id: %q", config.Branch, config.PrereleaseID)
+		}
+
+		channels = append(channels, PrereleaseChannel{
+			Branch:       compiled,
+			PrereleaseID: config.PrereleaseID,
+		})
+	}
+
+	return channels, nil
+}
+
+// channelPrereleaseID returns the prerelease identifier of the first channel matching
+// the dest branch, or the prerelease id param if none matches.
+func channelPrereleaseID(params Params, destBranch string) string {
+	for _, channel := range params.PrereleaseChannels {
+		if channel.Branch.MatchString(destBranch) {
+			return channel.PrereleaseID
+		}
+	}
+
+	return params.PrereleaseID
+}
@@ -0,0 +1,56 @@
+package generate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/trace"
+
+	"github.com/apex/log"
+)
+
+// freeTag returns the tag when it does not exist yet. Otherwise prereleases and
+// builds advance their counter until a free tag is found, also returning the
+// existing tags skipped along the way. Any other existing tag is an error.
+func freeTag(gc git.Git, tag string, isPrerelease bool, tr *trace.Trace) (string, []string, error) {
+	var skipped []string
+
+	for gc.TagExists(tag) {
+		next, ok := nextCounter(tag, isPrerelease)
+		if !ok {
+			return "", nil, fmt.Errorf("tag %s already exists", tag)
+		}
+
+		log.Warnf("tag %s already exists, advancing to %s", tag, next)
+
+		tr.Notef("tag %s already exists, advanced to %s", tag, next)
+
+		skipped = append(skipped, tag)
+		tag = next
+	}
+
+	return tag, skipped, nil
+}
+
+// nextCounter increments the trailing counter of a prerelease tag, e.g.
+// v1.3.0-pre.2, or of a tag with build metadata, e.g. v1.2.3+4. Final
+// versions have no counter to increment.
+func nextCounter(tag string, isPrerelease bool) (string, bool) {
+	if !isPrerelease && !strings.Contains(tag, "+") {
+		return "", false
+	}
+
+	i := strings.LastIndexAny(tag, ".+")
+	if i < 0 {
+		return "", false
+	}
+
+	counter, err := strconv.ParseUint(tag[i+1:], 10, 64)
+	if err != nil {
+		return "", false
+	}
+
+	return tag[:i+1] + strconv.FormatUint(counter+1, 10), true
+}
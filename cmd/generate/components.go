@@ -0,0 +1,176 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gandarez/semver-action/internal/glob"
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/strategy"
+	"github.com/gandarez/semver-action/pkg/trace"
+
+	"github.com/apex/log"
+)
+
+type (
+	// Component contains a monorepo component with its own tag namespace.
+	Component struct {
+		Name   string
+		Paths  []glob.Glob
+		Prefix string
+	}
+
+	// ComponentResult contains the result of a monorepo component.
+	ComponentResult struct {
+		PreviousTag  string   `json:"previous_tag"`
+		AncestorTag  string   `json:"ancestor_tag"`
+		SemverTag    string   `json:"semver_tag"`
+		IsPrerelease bool     `json:"is_prerelease"`
+		SkippedTags  []string `json:"skipped_tags,omitempty"`
+		TagCreated   bool     `json:"tag_created"`
+		TagPushed    bool     `json:"tag_pushed"`
+	}
+
+	componentConfig struct {
+		Name   string   `json:"name"`
+		Paths  []string `json:"paths"`
+		Prefix string   `json:"prefix"`
+	}
+)
+
+// parseComponents parses the JSON list of components. Prefix defaults to `<name>/v`.
+func parseComponents(data string) ([]Component, error) {
+	var configs []componentConfig
+
+	if err := json.Unmarshal([]byte(data), &configs); err != nil {
+		return nil, err
+	}
+
+	var (
+		components = make([]Component, 0, len(configs))
+		names      = make(map[string]bool)
+		prefixes   = make(map[string]string)
+	)
+
+	for i, config := range configs {
+		if config.Name == "" {
+			return nil, fmt.Errorf("component at index %d has no name", i)
+		}
+
+		if names[config.Name] {
+			return nil, fmt.Errorf("duplicated component %q", config.Name)
+		}
+
+		names[config.Name] = true
+
+		if len(config.Paths) == 0 {
+			return nil, fmt.Errorf("component %q has no paths", config.Name)
+		}
+
+		prefix := config.Prefix
+		if prefix == "" {
+			prefix = config.Name + "/v"
+		}
+
+		if other, ok := prefixes[prefix]; ok {
+			return nil, fmt.Errorf("component %q has the same tag prefix of %q: %s", config.Name, other, prefix)
+		}
+
+		prefixes[prefix] = config.Name
+
+		paths := make([]glob.Glob, len(config.Paths))
+
+		for j, path := range config.Paths {
+			compiled, err := glob.Compile(path)
+			if err != nil {
+				return nil, fmt.Errorf("component %q: %s", config.Name, err)
+			}
+
+			paths[j] = compiled
+		}
+
+		components = append(components, Component{
+			Name:   config.Name,
+			Paths:  paths,
+			Prefix: prefix,
+		})
+	}
+
+	return components, nil
+}
+
+// TagComponents returns the calculated semantic version of every monorepo component
+// whose paths changed since its latest tag. Components without changes are omitted.
+func TagComponents(params Params, gc git.Git) (Result, error) {
+	components := make(map[string]ComponentResult)
+	tr := &trace.Trace{}
+
+	branchingStrategy, err := NewStrategy(params)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to decide branching strategy: %s", err)
+	}
+
+	features := strategy.FeaturesOf(branchingStrategy)
+
+	for _, component := range params.Components {
+		componentParams := params
+		componentParams.Components = nil
+		componentParams.Prefix = component.Prefix
+		componentParams.IncludeTagPattern = component.Prefix + "[0-9]*"
+
+		latestTag := gc.LatestTag(componentParams.IncludeTagPattern, ExcludeTagPattern(componentParams, features))
+
+		files, err := gc.ChangedFiles(latestTag, params.CommitSha)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to get changed files of component %q: %s", component.Name, err)
+		}
+
+		if !glob.MatchAny(component.Paths, files) {
+			log.Infof("component %q has no changes since %q", component.Name, latestTag)
+
+			skipped := &trace.Trace{LatestTag: latestTag}
+			skipped.Notef("no changes in %s since latest tag", globsString(component.Paths))
+
+			tr.AddComponent(component.Name, skipped)
+
+			continue
+		}
+
+		log.Debugf("component %q changed since %q\n", component.Name, latestTag)
+
+		result, err := Tag(componentParams, gc)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to tag component %q: %s", component.Name, err)
+		}
+
+		tr.AddComponent(component.Name, result.Trace)
+
+		if result.SemverTag == "" {
+			continue
+		}
+
+		components[component.Name] = ComponentResult{
+			PreviousTag:  result.PreviousTag,
+			AncestorTag:  result.AncestorTag,
+			SemverTag:    result.SemverTag,
+			IsPrerelease: result.IsPrerelease,
+			SkippedTags:  result.SkippedTags,
+		}
+	}
+
+	return Result{
+		Components: components,
+		Trace:      tr,
+	}, nil
+}
+
+// globsString joins the globs separated by comma.
+func globsString(globs []glob.Glob) string {
+	patterns := make([]string, len(globs))
+	for i, g := range globs {
+		patterns[i] = g.String()
+	}
+
+	return strings.Join(patterns, ", ")
+}
@@ -0,0 +1,124 @@
+package generate_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/semver-action/cmd/generate"
+	"github.com/gandarez/semver-action/internal/glob"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagComponents(t *testing.T) {
+	p, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	p.CommitSha = "81918ffc"
+	p.BranchingModel = "trunk-based"
+	p.Components = []generate.Component{
+		{
+			Name:   "api",
+			Paths:  []glob.Glob{glob.MustCompile("services/api/**")},
+			Prefix: "api/v",
+		},
+		{
+			Name:   "web",
+			Paths:  []glob.Glob{glob.MustCompile("services/web/**")},
+			Prefix: "web/v",
+		},
+		{
+			Name:   "worker",
+			Paths:  []glob.Glob{glob.MustCompile("services/worker/**")},
+			Prefix: "worker/v",
+		},
+	}
+
+	latestTags := map[string]string{
+		"api/v[0-9]*":    "api/v1.4.0",
+		"web/v[0-9]*":    "web/v2.0.1",
+		"worker/v[0-9]*": "",
+	}
+
+	gc := initGitClientMock(t, "", "", "master", "feature/some", p.CommitSha)
+	gc.LatestTagFn = func(include, exclude string) string {
+		tag, ok := latestTags[include]
+		require.True(t, ok, include)
+
+		return tag
+	}
+	gc.ChangedFilesFn = func(from, to string) ([]string, error) {
+		assert.Equal(t, "81918ffc", to)
+
+		switch from {
+		case "api/v1.4.0":
+			return []string{"services/api/main.go", "README.md"}, nil
+		case "web/v2.0.1":
+			return []string{"README.md"}, nil
+		default:
+			return []string{"services/worker/main.go"}, nil
+		}
+	}
+
+	result, err := generate.TagComponents(p, gc)
+	require.NoError(t, err)
+
+	require.NotNil(t, result.Trace)
+	require.Len(t, result.Trace.Components, 3)
+	assert.Equal(t, "api/v1.5.0", result.Trace.Components["api"].SemverTag)
+	assert.Equal(t, []string{"no changes in services/web/** since latest tag"}, result.Trace.Components["web"].Notes)
+
+	result.Trace = nil
+
+	assert.Equal(t, generate.Result{
+		Components: map[string]generate.ComponentResult{
+			"api": {
+				PreviousTag: "api/v1.4.0",
+				SemverTag:   "api/v1.5.0",
+			},
+			"worker": {
+				PreviousTag: "worker/v0.0.0",
+				SemverTag:   "worker/v0.1.0",
+			},
+		},
+	}, result)
+}
+
+func TestTagComponents_GitFlow(t *testing.T) {
+	p, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	p.Components = []generate.Component{
+		{
+			Name:   "api",
+			Paths:  []glob.Glob{glob.MustCompile("services/api/**")},
+			Prefix: "api/v",
+		},
+	}
+
+	gc := initGitClientMock(t, "api/v1.4.1-pre.1", "api/v1.4.1-pre.1", "develop", "doc/some", p.CommitSha)
+	gc.AncestorTagFn = func(include, exclude, branch string) string {
+		assert.Equal(t, "api/v[0-9]*-pre*", include)
+
+		return "api/v1.4.1-pre.1"
+	}
+	gc.ChangedFilesFn = func(from, to string) ([]string, error) {
+		return []string{"services/api/README.md"}, nil
+	}
+
+	result, err := generate.TagComponents(p, gc)
+	require.NoError(t, err)
+
+	result.Trace = nil
+
+	assert.Equal(t, generate.Result{
+		Components: map[string]generate.ComponentResult{
+			"api": {
+				PreviousTag:  "api/v1.4.1-pre.1",
+				AncestorTag:  "api/v1.4.1-pre.1",
+				SemverTag:    "api/v1.4.1-pre.2",
+				IsPrerelease: true,
+			},
+		},
+	}, result)
+}
@@ -0,0 +1,237 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gandarez/semver-action/pkg/regex"
+	"github.com/gandarez/semver-action/pkg/strategy"
+
+	"gopkg.in/yaml.v3"
+)
+
+// nolint: gochecknoglobals
+var (
+	// configFiles are looked up in repo dir when config_file input is not set.
+	configFiles = []string{".semver.yml", ".semver.yaml", ".semver.json"}
+	// configKeys maps the inputs allowed in the config file to their validation.
+	configKeys = map[string]func(value string) error{
+		"branching_model":     validateBranchingModel,
+		"initial_development": validateBool,
+		"patch_regex":         validateRegex,
+		"minor_regex":         validateRegex,
+		"major_regex":         validateRegex,
+		"build_regex":         validateRegex,
+		"hotfix_regex":        validateRegex,
+		"exclude_regex":       validateRegex,
+		"maintenance_regex":   validateRegex,
+		"rules":               validateRules,
+		"label_bumps":         validateLabelBumps,
+		"calver_format":       validateCalVerFormat,
+		"prefix":              validateAny,
+		"prerelease_id":       validateAny,
+		"prerelease_channels": validatePrereleaseChannels,
+		"main_branch_name":    validateNotEmpty,
+		"develop_branch_name": validateNotEmpty,
+		"include_tag_pattern": validateAny,
+		"exclude_tag_pattern": validateAny,
+		"go_module":           validateGoModule,
+		"go_module_dir":       validateAny,
+		"api_diff":            validateAPIDiff,
+		"include_paths":       validateGlobs,
+		"exclude_paths":       validateGlobs,
+		"unmatched_paths":     validateUnmatchedPaths,
+	}
+)
+
+// findConfigFile returns the config file path. When fp is empty it looks up
+// the default config files in repo dir and returns empty if none exists.
+// Relative paths are relative to repo dir.
+func findConfigFile(repoDir, fp string) (string, error) {
+	if fp != "" {
+		if !filepath.IsAbs(fp) {
+			fp = filepath.Join(repoDir, fp)
+		}
+
+		if _, err := os.Stat(fp); err != nil {
+			return "", fmt.Errorf("failed to find config file: %s", err)
+		}
+
+		return fp, nil
+	}
+
+	for _, name := range configFiles {
+		fp := filepath.Join(repoDir, name)
+
+		_, err := os.Stat(fp)
+		if err == nil {
+			return fp, nil
+		}
+
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", fmt.Errorf("failed to find config file: %s", err)
+		}
+	}
+
+	return "", nil
+}
+
+// loadConfig reads the YAML or JSON config file into a map of input values.
+// Errors contain the line of the offending key.
+func loadConfig(fp string) (map[string]string, error) {
+	data, err := os.ReadFile(fp) // nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %s", err)
+	}
+
+	var doc yaml.Node
+
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %s", fp, err)
+	}
+
+	config := make(map[string]string)
+
+	// empty file
+	if len(doc.Content) == 0 {
+		return config, nil
+	}
+
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s:%d: config file must be a mapping of keys to values", fp, root.Line)
+	}
+
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		key, value := root.Content[i], root.Content[i+1]
+
+		validate, ok := configKeys[key.Value]
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: unknown key %q, must be one of: %s", fp, key.Line, key.Value, strings.Join(configKeyNames(), ", "))
+		}
+
+		if _, ok := config[key.Value]; ok {
+			return nil, fmt.Errorf("%s:%d: duplicated key %q", fp, key.Line, key.Value)
+		}
+
+		if value.Kind != yaml.ScalarNode {
+			return nil, fmt.Errorf("%s:%d: %s must be a string", fp, value.Line, key.Value)
+		}
+
+		if err := validate(value.Value); err != nil {
+			return nil, fmt.Errorf("%s:%d: invalid %s value %q: %s", fp, value.Line, key.Value, value.Value, err)
+		}
+
+		config[key.Value] = value.Value
+	}
+
+	return config, nil
+}
+
+// withConfig returns an input lookup that falls back to the config values.
+func withConfig(input func(name string) string, config map[string]string) func(name string) string {
+	return func(name string) string {
+		if value := input(name); value != "" {
+			return value
+		}
+
+		return config[name]
+	}
+}
+
+func configKeyNames() []string {
+	names := make([]string, 0, len(configKeys))
+	for name := range configKeys {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// validateBranchingModel looks up the registry on every call, so strategies
+// registered after this package is initialized are valid too.
+func validateBranchingModel(value string) error {
+	if names := strategy.Names(); !stringInSlice(value, names) {
+		return fmt.Errorf("must be one of: %s", strings.Join(names, ", "))
+	}
+
+	return nil
+}
+
+func validateAPIDiff(value string) error {
+	if !stringInSlice(value, validAPIDiffModes) {
+		return fmt.Errorf("must be one of: %s", strings.Join(validAPIDiffModes, ", "))
+	}
+
+	return nil
+}
+
+func validateGlobs(value string) error {
+	_, err := parseGlobs(value)
+	return err
+}
+
+func validateUnmatchedPaths(value string) error {
+	if !stringInSlice(value, validUnmatchedPaths) {
+		return fmt.Errorf("must be one of: %s", strings.Join(validUnmatchedPaths, ", "))
+	}
+
+	return nil
+}
+
+func validateGoModule(value string) error {
+	if !stringInSlice(value, validGoModuleModes) {
+		return fmt.Errorf("must be one of: %s", strings.Join(validGoModuleModes, ", "))
+	}
+
+	return nil
+}
+
+func validatePrereleaseChannels(value string) error {
+	_, err := parsePrereleaseChannels(value)
+	return err
+}
+
+func validateRules(value string) error {
+	_, err := parseRules(value)
+	return err
+}
+
+func validateLabelBumps(value string) error {
+	_, err := parseLabelBumps(value)
+	return err
+}
+
+func validateRegex(value string) error {
+	_, err := regex.Compile(value)
+	return err
+}
+
+func validateCalVerFormat(value string) error {
+	_, err := strategy.ParseCalVerFormat(value)
+	return err
+}
+
+func validateBool(value string) error {
+	_, err := strconv.ParseBool(value)
+	return err
+}
+
+func validateNotEmpty(value string) error {
+	if value == "" {
+		return errors.New("must not be empty")
+	}
+
+	return nil
+}
+
+func validateAny(string) error {
+	return nil
+}
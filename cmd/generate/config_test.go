@@ -0,0 +1,193 @@
+package generate_test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gandarez/semver-action/cmd/generate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadParams_ConfigFile(t *testing.T) {
+	repoDir := t.TempDir()
+
+	writeConfigFile(t, repoDir, ".semver.yml", `
+branching_model: trunk-based
+patch_regex: "^fix/.+"
+prefix: release-
+prerelease_id: rc
+main_branch_name: main
+include_tag_pattern: "release-*"
+`)
+
+	require.NoError(t, os.Setenv("INPUT_REPO_DIR", repoDir))
+	require.NoError(t, os.Setenv("INPUT_PREFIX", "v"))
+
+	defer func() {
+		require.NoError(t, os.Unsetenv("INPUT_REPO_DIR"))
+		require.NoError(t, os.Unsetenv("INPUT_PREFIX"))
+	}()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(repoDir, ".semver.yml"), params.ConfigFile)
+	assert.Equal(t, "trunk-based", params.BranchingModel)
+	assert.Equal(t, "^fix/.+", params.PatchPattern.String())
+	assert.Equal(t, "rc", params.PrereleaseID)
+	assert.Equal(t, "main", params.MainBranchName)
+	assert.Equal(t, "release-*", params.IncludeTagPattern)
+	// explicit inputs take precedence over config file
+	assert.Equal(t, "v", params.Prefix)
+	// defaults are kept for keys not set
+	assert.Equal(t, "develop", params.DevelopBranchName)
+	assert.Equal(t, "(?i)^(.+:)?(feature/.+)", params.MinorPattern.String())
+}
+
+func TestLoadParams_ConfigFile_JSONValues(t *testing.T) {
+	repoDir := t.TempDir()
+
+	writeConfigFile(t, repoDir, ".semver.yml", `
+branching_model: trunk-based
+prerelease_channels: '[{"branch": "^beta$", "prerelease_id": "beta"}]'
+rules: '[{"source": "^docs/", "method": "none"}]'
+label_bumps: '{"breaking": "major"}'
+`)
+
+	require.NoError(t, os.Setenv("INPUT_REPO_DIR", repoDir))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_REPO_DIR")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	require.Len(t, params.PrereleaseChannels, 1)
+	assert.Equal(t, "beta", params.PrereleaseChannels[0].PrereleaseID)
+
+	require.Len(t, params.Rules, 1)
+	assert.Equal(t, "^docs/", params.Rules[0].Source.String())
+	assert.Empty(t, params.Rules[0].Method)
+
+	assert.Equal(t, map[string]string{"breaking": "major"}, params.LabelBumps)
+}
+
+func TestLoadParams_ConfigFile_Input(t *testing.T) {
+	repoDir := t.TempDir()
+
+	writeConfigFile(t, repoDir, ".semver.yml", `prefix: ignored-`)
+	writeConfigFile(t, repoDir, "ci/semver.json", `{"prefix": "api-v", "develop_branch_name": "dev"}`)
+
+	require.NoError(t, os.Setenv("INPUT_REPO_DIR", repoDir))
+	require.NoError(t, os.Setenv("INPUT_CONFIG_FILE", "ci/semver.json"))
+
+	defer func() {
+		require.NoError(t, os.Unsetenv("INPUT_REPO_DIR"))
+		require.NoError(t, os.Unsetenv("INPUT_CONFIG_FILE"))
+	}()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, filepath.Join(repoDir, "ci/semver.json"), params.ConfigFile)
+	assert.Equal(t, "api-v", params.Prefix)
+	assert.Equal(t, "dev", params.DevelopBranchName)
+}
+
+func TestLoadParams_ConfigFile_Default(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_REPO_DIR", t.TempDir()))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_REPO_DIR")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Empty(t, params.ConfigFile)
+}
+
+func TestLoadParams_ConfigFile_Invalid(t *testing.T) {
+	tests := map[string]struct {
+		Content  string
+		Expected string
+	}{
+		"unknown key": {
+			Content: "prefix: v\nprefx: v\n",
+			Expected: "invalid config file: %s:2: unknown key \"prefx\", must be one of:" +
+				" api_diff, branching_model, build_regex, calver_format, develop_branch_name, exclude_paths, exclude_regex, exclude_tag_pattern," +
+				" go_module, go_module_dir, hotfix_regex, include_paths, include_tag_pattern, initial_development, label_bumps, main_branch_name, maintenance_regex, major_regex," +
+				" minor_regex, patch_regex, prefix, prerelease_channels, prerelease_id, rules, unmatched_paths",
+		},
+		"invalid branching model": {
+			Content:  "prefix: v\n\nbranching_model: release-flow\n",
+			Expected: "invalid config file: %s:3: invalid branching_model value \"release-flow\": must be one of: git-flow, trunk-based, github-flow, calver",
+		},
+		"invalid regex": {
+			Content:  "minor_regex: \"[\"\n",
+			Expected: "invalid config file: %s:1: invalid minor_regex value \"[\": failed to compile regex \"[\": error parsing regexp: unterminated [] set in `[`",
+		},
+		"invalid rules": {
+			Content:  "rules: '[{\"source\": \"^docs/\", \"method\": \"feature\"}]'\n",
+			Expected: "invalid config file: %s:1: invalid rules value \"[{\\\"source\\\": \\\"^docs/\\\", \\\"method\\\": \\\"feature\\\"}]\": rule at index 0 has invalid method: \"feature\"",
+		},
+		"invalid label bumps": {
+			Content:  "label_bumps: '{\"breaking\": \"huge\"}'\n",
+			Expected: "invalid config file: %s:1: invalid label_bumps value \"{\\\"breaking\\\": \\\"huge\\\"}\": breaking: huge",
+		},
+		"empty branch name": {
+			Content:  "main_branch_name: \"\"\n",
+			Expected: "invalid config file: %s:1: invalid main_branch_name value \"\": must not be empty",
+		},
+		"not a string": {
+			Content:  "prefix:\n  - v\n",
+			Expected: "invalid config file: %s:2: prefix must be a string",
+		},
+		"duplicated key": {
+			Content:  "prefix: v\nprefix: w\n",
+			Expected: "invalid config file: %s:2: duplicated key \"prefix\"",
+		},
+		"not a mapping": {
+			Content:  "- prefix\n",
+			Expected: "invalid config file: %s:1: config file must be a mapping of keys to values",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repoDir := t.TempDir()
+			fp := writeConfigFile(t, repoDir, ".semver.yml", test.Content)
+
+			require.NoError(t, os.Setenv("INPUT_REPO_DIR", repoDir))
+			defer func() { require.NoError(t, os.Unsetenv("INPUT_REPO_DIR")) }()
+
+			_, err := generate.LoadParams()
+			require.Error(t, err)
+
+			assert.Equal(t, fmt.Sprintf(test.Expected, fp), err.Error())
+		})
+	}
+}
+
+func TestLoadParams_ConfigFile_NotFound(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_REPO_DIR", t.TempDir()))
+	require.NoError(t, os.Setenv("INPUT_CONFIG_FILE", "missing.yml"))
+
+	defer func() {
+		require.NoError(t, os.Unsetenv("INPUT_REPO_DIR"))
+		require.NoError(t, os.Unsetenv("INPUT_CONFIG_FILE"))
+	}()
+
+	_, err := generate.LoadParams()
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "failed to find config file")
+}
+
+func writeConfigFile(t *testing.T, dir, name, content string) string {
+	fp := filepath.Join(dir, name)
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(fp), 0755))
+	require.NoError(t, os.WriteFile(fp, []byte(content), 0600))
+
+	return fp
+}
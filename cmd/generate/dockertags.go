@@ -0,0 +1,60 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gandarez/semver-action/internal/dockertag"
+	"github.com/gandarez/semver-action/pkg/git"
+
+	"github.com/apex/log"
+	"github.com/blang/semver/v4"
+)
+
+// DockerTags renders the docker tags of the calculated version, when enabled,
+// joined by the docker tags separator. Floating tags are only rendered when no
+// higher release exists in the repo.
+func DockerTags(params Params, gc git.Git, result Result) (Result, error) {
+	if len(params.DockerTags) == 0 || result.SemverTag == "" {
+		return result, nil
+	}
+
+	version, err := semver.ParseTolerant(strings.TrimPrefix(result.SemverTag, params.Prefix))
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to parse tag %q or not valid semantic version: %s", result.SemverTag, err)
+	}
+
+	pattern := params.IncludeTagPattern
+	if pattern == "" {
+		pattern = params.Prefix + "[0-9]*"
+	}
+
+	tags, err := gc.Tags(pattern)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to list releases: %s", err)
+	}
+
+	var releases []semver.Version
+
+	for _, tag := range tags {
+		parsed, err := semver.ParseTolerant(strings.TrimPrefix(tag, params.Prefix))
+		if err != nil || len(parsed.Pre) > 0 {
+			continue
+		}
+
+		releases = append(releases, parsed)
+	}
+
+	rendered := dockertag.Render(version, dockertag.Config{
+		Kinds:     params.DockerTags,
+		Image:     params.DockerImage,
+		CommitSha: params.CommitSha,
+		Releases:  releases,
+	})
+
+	log.Debugf("docker tags: %q\n", rendered)
+
+	result.DockerTags = strings.Join(rendered, params.DockerTagsSeparator)
+
+	return result, nil
+}
@@ -0,0 +1,94 @@
+package generate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/gandarez/semver-action/cmd/generate"
+	"github.com/gandarez/semver-action/internal/dockertag"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDockerTags(t *testing.T) {
+	tests := map[string]struct {
+		SemverTag string
+		Separator string
+		Tags      []string
+		Expected  string
+	}{
+		"highest release": {
+			SemverTag: "v1.4.2",
+			Separator: "\n",
+			Tags:      []string{"v1.4.1", "v1.3.0", "v1.5.0-pre.1"},
+			Expected:  "ghcr.io/owner/app:1.4.2\nghcr.io/owner/app:1.4\nghcr.io/owner/app:1\nghcr.io/owner/app:latest",
+		},
+		"not the highest release": {
+			SemverTag: "v1.3.5",
+			Separator: ",",
+			Tags:      []string{"v1.3.4", "v1.4.2", "invalid"},
+			Expected:  "ghcr.io/owner/app:1.3.5,ghcr.io/owner/app:1.3",
+		},
+		"prerelease": {
+			SemverTag: "v1.5.0-pre.3",
+			Separator: ",",
+			Tags:      []string{"v1.4.2"},
+			Expected:  "ghcr.io/owner/app:1.5.0-pre.3,ghcr.io/owner/app:edge",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gc := &gitClientMock{
+				TagsFn: func(pattern string) ([]string, error) {
+					assert.Equal(t, "v[0-9]*", pattern)
+
+					return test.Tags, nil
+				},
+			}
+
+			result, err := generate.DockerTags(generate.Params{
+				Prefix: "v",
+				DockerTags: []dockertag.Kind{
+					dockertag.Version,
+					dockertag.Minor,
+					dockertag.Major,
+					dockertag.Latest,
+					dockertag.Edge,
+				},
+				DockerImage:         "ghcr.io/owner/app",
+				DockerTagsSeparator: test.Separator,
+			}, gc, generate.Result{SemverTag: test.SemverTag})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result.DockerTags)
+		})
+	}
+}
+
+func TestDockerTags_Disabled(t *testing.T) {
+	gc := &gitClientMock{}
+
+	result, err := generate.DockerTags(generate.Params{Prefix: "v"}, gc, generate.Result{SemverTag: "v1.4.2"})
+	require.NoError(t, err)
+
+	assert.Empty(t, result.DockerTags)
+	assert.Zero(t, gc.TagsFnInvoked)
+}
+
+func TestDockerTags_TagsErr(t *testing.T) {
+	gc := &gitClientMock{
+		TagsFn: func(pattern string) ([]string, error) {
+			return nil, errors.New("exit status 128")
+		},
+	}
+
+	_, err := generate.DockerTags(generate.Params{
+		Prefix:     "v",
+		DockerTags: []dockertag.Kind{dockertag.Version},
+	}, gc, generate.Result{SemverTag: "v1.4.2"})
+	require.Error(t, err)
+
+	assert.Equal(t, "failed to list releases: exit status 128", err.Error())
+}
@@ -2,9 +2,12 @@ package generate
 
 import (
 	"fmt"
+	"strings"
 
-	"github.com/gandarez/semver-action/internal/strategy"
+	"github.com/gandarez/semver-action/pkg/actions"
 	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/strategy"
+	"github.com/gandarez/semver-action/pkg/trace"
 
 	"github.com/apex/log"
 	"github.com/blang/semver/v4"
@@ -14,10 +17,42 @@ const initialTag = "0.0.0"
 
 // Result contains the result of Run().
 type Result struct {
-	PreviousTag  string
-	AncestorTag  string
-	SemverTag    string
-	IsPrerelease bool
+	PreviousTag  string `json:"previous_tag"`
+	AncestorTag  string `json:"ancestor_tag"`
+	SemverTag    string `json:"semver_tag"`
+	IsPrerelease bool   `json:"is_prerelease"`
+	// LatestTag is the tag the version was calculated from, empty when no tag
+	// was found. Unlike PreviousTag, it's the actual tag name.
+	LatestTag string `json:"-"`
+	// SkippedTags contains the calculated tags that already existed, in the
+	// order they were skipped to reach SemverTag.
+	SkippedTags []string `json:"skipped_tags,omitempty"`
+	// Version is SemverTag without prefix, split into its parts below.
+	Version    string `json:"version"`
+	Major      uint64 `json:"major"`
+	Minor      uint64 `json:"minor"`
+	Patch      uint64 `json:"patch"`
+	Prerelease string `json:"prerelease"`
+	Build      string `json:"build"`
+	// Bump is the part bumped since the previous tag: major, minor, patch,
+	// prerelease, build, final or none.
+	Bump    string `json:"bump"`
+	IsMajor bool   `json:"is_major"`
+	IsMinor bool   `json:"is_minor"`
+	IsPatch bool   `json:"is_patch"`
+	// Components contains the result of each changed component in monorepo mode.
+	Components map[string]ComponentResult `json:"components,omitempty"`
+	// VersionFiles contains the version files changed when set.
+	VersionFiles []string `json:"version_files,omitempty"`
+	// DockerTags contains the docker tags joined by the docker tags separator
+	// when enabled.
+	DockerTags string `json:"docker_tags,omitempty"`
+	// Changelog contains the Markdown changelog when enabled.
+	Changelog  string `json:"changelog,omitempty"`
+	TagCreated bool   `json:"tag_created"`
+	TagPushed  bool   `json:"tag_pushed"`
+	// Trace records how the version was decided.
+	Trace *trace.Trace `json:"-"`
 }
 
 // Run generates a semantic version using the commit sha.
@@ -27,6 +62,23 @@ func Run() (Result, error) {
 		return Result{}, fmt.Errorf("failed to load parameters: %s", err)
 	}
 
+	result, err := RunWithParams(params)
+	if err != nil {
+		return Result{}, err
+	}
+
+	// the command line prints the trace itself, so it's only logged in the action
+	if params.Explain {
+		log.Infof("explain:\n%s", result.Trace.Text())
+	}
+
+	return result, nil
+}
+
+// RunWithParams generates a semantic version using the given params.
+func RunWithParams(params Params) (Result, error) {
+	var err error
+
 	if params.Debug {
 		log.SetLevel(log.DebugLevel)
 		log.Debug("debug logs enabled\n")
@@ -35,8 +87,42 @@ func Run() (Result, error) {
 	log.Debug(params.String())
 
 	gc := git.New(params.RepoDir)
+	gc.MergeFormat = params.MergeFormat
+
+	var result Result
+
+	if len(params.Components) > 0 {
+		result, err = TagComponents(params, gc)
+	} else {
+		result, err = Tag(params, gc)
+	}
+
+	if err != nil {
+		return Result{}, err
+	}
+
+	result = SplitVersion(params, result)
+
+	if err := CheckGoModule(params, result); err != nil {
+		return Result{}, err
+	}
 
-	return Tag(params, gc)
+	result, err = DockerTags(params, gc, result)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result, err = WriteVersionFiles(params, result)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result, err = Changelog(params, gc, result)
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Release(params, gc, result)
 }
 
 // Tag returns the calculated semantic version.
@@ -50,56 +136,197 @@ func Tag(params Params, gc git.Git) (Result, error) {
 		return Result{}, fmt.Errorf("current folder is not a git repository")
 	}
 
-	dest, err := gc.CurrentBranch()
+	tr := &trace.Trace{}
+
+	source, dest, err := branches(params, gc, tr)
 	if err != nil {
-		return Result{}, fmt.Errorf("failed to extract dest branch from commit: %s", err)
+		return Result{}, err
 	}
 
 	log.Debugf("dest branch: %q\n", dest)
+	log.Debugf("source branch: %q\n", source)
 
-	source, err := gc.SourceBranch(params.CommitSha)
+	// commit trailers apply before consulting the strategy
+	overrides, err := readTrailers(gc, params.CommitSha)
 	if err != nil {
-		return Result{}, fmt.Errorf("failed to extract source branch from commit: %s", err)
+		return Result{}, err
 	}
 
-	log.Debugf("source branch: %q\n", source)
+	if overrides.Bump == "skip" {
+		log.Info("no version bump required")
 
-	branchingStrategy, err := strategy.New(strategy.Configuration{
-		Bump:              params.Bump,
-		BranchingModel:    params.BranchingModel,
-		MainBranchName:    params.MainBranchName,
-		DevelopBranchName: params.DevelopBranchName,
-		PatchPattern:      params.PatchPattern,
-		MinorPattern:      params.MinorPattern,
-		MajorPattern:      params.MajorPattern,
-		BuildPattern:      params.BuildPattern,
-		HotfixPattern:     params.HotfixPattern,
-		ExcludePattern:    params.ExcludePattern,
-	})
+		tr.Decide("commit trailer skips the bump", nil)
+		tr.Notef("no version bump required")
+
+		return Result{Trace: tr}, nil
+	}
+
+	if overrides.Bump != "" {
+		log.Debugf("commit trailer requires %s bump\n", overrides.Bump)
+
+		tr.Notef("commit trailer requires %s bump", overrides.Bump)
+
+		params.Bump = overrides.Bump
+	}
+
+	branchingStrategy, err := NewStrategy(params)
 	if err != nil {
 		return Result{}, fmt.Errorf("failed to decide branching strategy: %s", err)
 	}
 
+	features := strategy.FeaturesOf(branchingStrategy)
+
 	log.Debugf("using branching strategy: %q\n", branchingStrategy.Name())
 
-	method, version := branchingStrategy.DetermineBumpStrategy(source, dest)
+	tr.Strategy = branchingStrategy.Name()
+
+	// pull requests not merged yet, e.g. closed without merging, are only
+	// released by strategies with previews
+	if event := params.Event; event != nil && event.IsPullRequest() && !event.PullRequest.Merged && features.PreviewTagPattern == "" {
+		log.Infof("pull request #%d is not merged, no version bump required", event.PullRequest.Number)
+
+		tr.Decide(fmt.Sprintf("pull request #%d is not merged", event.PullRequest.Number), nil)
+		tr.Notef("no version bump required")
+
+		return Result{Trace: tr}, nil
+	}
+
+	includeTagPattern := params.IncludeTagPattern
+
+	// maintenance branches only look up the tags of their own version line
+	var (
+		line          string
+		isMaintenance bool
+	)
+
+	if features.MaintenanceLines {
+		line, isMaintenance = strategy.MaintenanceLine(params.MaintenancePattern, dest)
+	}
+
+	if isMaintenance {
+		includeTagPattern = params.Prefix + line + ".*"
+
+		log.Debugf("maintenance line %s, restricting tags to %q\n", line, includeTagPattern)
+
+		tr.Notef("maintenance line %s, restricting tags to %s", line, includeTagPattern)
+	}
+
+	latestTag := gc.LatestTag(includeTagPattern, ExcludeTagPattern(params, features))
+
+	tr.LatestTag = latestTag
+
+	if overrides.ReleaseAs != nil {
+		result, err := releaseAs(params, gc, latestTag, overrides.ReleaseAs)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to tag: %s", err)
+		}
+
+		tr.Decide(fmt.Sprintf("commit trailer releases as %s", overrides.ReleaseAs), nil)
+		tr.SemverTag = result.SemverTag
+
+		result.LatestTag = latestTag
+		result.Trace = tr
+
+		return result, nil
+	}
+
+	var commits []git.Commit
+
+	if params.BumpSource == "commits" {
+		commits, err = gc.Commits(latestTag, params.CommitSha)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to get commits since latest tag: %s", err)
+		}
+
+		log.Debugf("found %d commits since latest tag %q\n", len(commits), latestTag)
+
+		tr.Notef("found %d commits since latest tag", len(commits))
+	}
+
+	method, version := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+		SourceBranch: source,
+		DestBranch:   dest,
+		Commits:      commits,
+		Labels:       params.Labels,
+		Trace:        tr,
+	})
+
+	method, version, err = filterPaths(params, features, gc, dest, latestTag, params.Bump, method, version, tr)
+	if err != nil {
+		return Result{}, err
+	}
+
+	method, version, err = apiDiff(params, features, gc, latestTag, params.Bump, method, version, tr)
+	if err != nil {
+		return Result{}, err
+	}
 
 	log.Debugf("method: %q, version: %q", method, version)
 
+	tr.Method = method
+	tr.Version = version
+
 	if method == "" && version == "" {
 		log.Info("no version bump required")
 
-		return Result{}, nil
+		tr.Notef("no version bump required")
+
+		return Result{Trace: tr}, nil
 	}
 
-	latestTag := gc.LatestTag(params.IncludeTagPattern, params.ExcludeTagPattern)
+	prereleaseID := channelPrereleaseID(params, dest)
+	if prereleaseID != params.PrereleaseID {
+		tr.Notef("dest branch uses prerelease channel %s", prereleaseID)
+	}
 
 	var tag *semver.Version
 
-	if latestTag == "" {
+	switch {
+	case features.Calendar:
+		// calendar versions are not always valid semantic versions, so calendar
+		// strategies read the latest tag itself
+		result, err := branchingStrategy.Tag(strategy.TagParams{
+			DestBranch:   dest,
+			Method:       method,
+			Prefix:       params.Prefix,
+			PrereleaseID: prereleaseID,
+			LatestTag:    latestTag,
+			Trace:        tr,
+		}, gc)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to tag: %s", err)
+		}
+
+		semverTag, skipped, err := freeTag(gc, result.SemverTag, result.IsPrerelease, tr)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to tag: %s", err)
+		}
+
+		tr.SemverTag = semverTag
+
+		return Result{
+			PreviousTag:  latestTag,
+			SemverTag:    semverTag,
+			IsPrerelease: result.IsPrerelease,
+			LatestTag:    latestTag,
+			SkippedTags:  skipped,
+			Trace:        tr,
+		}, nil
+	case latestTag == "" && isMaintenance:
+		parsed, err := semver.ParseTolerant(line)
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to parse maintenance line %q: %s", line, err)
+		}
+
+		tag = &parsed
+
+		tr.Notef("no tag found in maintenance line, starting from %s", tag)
+	case latestTag == "":
 		tag, _ = semver.New(initialTag)
-	} else {
-		parsed, err := semver.ParseTolerant(latestTag)
+
+		tr.Notef("no tag found, starting from %s", initialTag)
+	default:
+		parsed, err := semver.ParseTolerant(strings.TrimPrefix(latestTag, params.Prefix))
 		if err != nil {
 			return Result{}, fmt.Errorf("failed to parse tag %q or not valid semantic version: %s", latestTag, err)
 		}
@@ -109,6 +336,8 @@ func Tag(params Params, gc git.Git) (Result, error) {
 	previousTag := params.Prefix + tag.String()
 
 	if params.BaseVersion != nil {
+		tr.Notef("base version %s replaces %s", params.BaseVersion, tag)
+
 		tag = params.BaseVersion
 	}
 
@@ -116,10 +345,12 @@ func Tag(params Params, gc git.Git) (Result, error) {
 		DestBranch:   dest,
 		Method:       method,
 		Prefix:       params.Prefix,
-		PrereleaseID: params.PrereleaseID,
+		PrereleaseID: prereleaseID,
 		LatestTag:    latestTag,
 		Tag:          tag,
 		Version:      version,
+		PullRequest:  pullRequest(params),
+		Trace:        tr,
 	}, gc)
 	if err != nil {
 		return Result{}, fmt.Errorf("failed to tag: %s", err)
@@ -127,10 +358,119 @@ func Tag(params Params, gc git.Git) (Result, error) {
 
 	log.Debugf("result: %+v\n", result)
 
+	semverTag, skipped, err := freeTag(gc, result.SemverTag, result.IsPrerelease, tr)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to tag: %s", err)
+	}
+
+	tr.AncestorTag = result.AncestorTag
+	tr.SemverTag = semverTag
+
 	return Result{
 		PreviousTag:  previousTag,
 		AncestorTag:  result.AncestorTag,
-		SemverTag:    result.SemverTag,
+		SemverTag:    semverTag,
 		IsPrerelease: result.IsPrerelease,
+		LatestTag:    latestTag,
+		SkippedTags:  skipped,
+		Trace:        tr,
 	}, nil
 }
+
+// NewStrategy returns the branching strategy configured by the params.
+func NewStrategy(params Params) (strategy.Strategy, error) {
+	return strategy.New(strategy.Configuration{
+		Bump:               params.Bump,
+		BumpSource:         params.BumpSource,
+		BranchingModel:     params.BranchingModel,
+		MainBranchName:     params.MainBranchName,
+		DevelopBranchName:  params.DevelopBranchName,
+		PatchPattern:       params.PatchPattern,
+		MinorPattern:       params.MinorPattern,
+		MajorPattern:       params.MajorPattern,
+		BuildPattern:       params.BuildPattern,
+		HotfixPattern:      params.HotfixPattern,
+		ExcludePattern:     params.ExcludePattern,
+		Rules:              params.Rules,
+		LabelBumps:         params.LabelBumps,
+		InitialDevelopment: params.InitialDevelopment,
+		MaintenancePattern: params.MaintenancePattern,
+		CalVerFormat:       params.CalVerFormat,
+		Options:            params.StrategyOptions,
+		Now:                params.Now,
+	})
+}
+
+// ExcludeTagPattern returns the pattern of tags to ignore when looking up the
+// latest tag. Previews released by the strategy are ignored by default.
+func ExcludeTagPattern(params Params, features strategy.Features) string {
+	if params.ExcludeTagPattern == "" && features.PreviewTagPattern != "" {
+		return params.Prefix + features.PreviewTagPattern
+	}
+
+	return params.ExcludeTagPattern
+}
+
+// pullRequest returns the number of the unmerged pull request being built, or
+// zero. It's taken from the event payload when available, otherwise from the ref.
+func pullRequest(params Params) int {
+	if event := params.Event; event != nil {
+		if event.IsPullRequest() && !event.PullRequest.Merged {
+			return event.PullRequest.Number
+		}
+
+		return 0
+	}
+
+	return actions.PullRequestNumber(params.Ref)
+}
+
+// branches returns the source and dest branches. They're taken from the event
+// payload when available, otherwise they're scraped from the commit message.
+func branches(params Params, gc git.Git, tr *trace.Trace) (string, string, error) {
+	event := params.Event
+
+	switch {
+	case event != nil && event.IsPullRequest():
+		log.Debugf("using %s event payload, merged: %t\n", event.Name, event.PullRequest.Merged)
+
+		tr.Branches(event.PullRequest.Head.Ref, event.PullRequest.Base.Ref, event.Name+" event payload")
+
+		return event.PullRequest.Head.Ref, event.PullRequest.Base.Ref, nil
+	case event == nil && params.HeadRef != "" && params.BaseRef != "":
+		log.Debug("using head and base refs\n")
+
+		tr.Branches(params.HeadRef, params.BaseRef, "head and base refs")
+
+		return params.HeadRef, params.BaseRef, nil
+	case event != nil && event.Name == "push" && event.Branch() != "":
+		log.Debugf("using %s event payload\n", event.Name)
+
+		// push payload does not contain the source branch, so commit message
+		// is the only place to look for it. Squash and rebase merges don't have it.
+		source, err := gc.SourceBranch(params.CommitSha)
+		if err != nil {
+			log.Warnf("failed to extract source branch from commit: %s", err)
+
+			tr.Notef("failed to extract source branch from commit: %s", err)
+		}
+
+		tr.Branches(source, event.Branch(), event.Name+" event payload and commit message")
+
+		return source, event.Branch(), nil
+	}
+
+	dest, err := gc.CurrentBranch()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to extract dest branch from commit: %s", err)
+	}
+
+	source, err := gc.SourceBranch(params.CommitSha)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to extract source branch from commit: %s", err)
+	}
+
+	tr.Branches(source, dest, "current branch and commit message")
+
+	return source, dest, nil
+}
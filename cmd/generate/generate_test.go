@@ -2,16 +2,42 @@ package generate_test
 
 import (
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/gandarez/semver-action/cmd/generate"
-	"github.com/gandarez/semver-action/internal/regex"
+	"github.com/gandarez/semver-action/internal/glob"
+	"github.com/gandarez/semver-action/pkg/actions"
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/regex"
+	"github.com/gandarez/semver-action/pkg/strategy"
 
 	"github.com/blang/semver/v4"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
+func TestMain(m *testing.M) {
+	// tests run in github actions too, where the event of the workflow must not
+	// leak into the params
+	for _, env := range []string{
+		"GITHUB_SHA",
+		"GITHUB_EVENT_NAME",
+		"GITHUB_EVENT_PATH",
+		"GITHUB_REF",
+		"GITHUB_HEAD_REF",
+		"GITHUB_BASE_REF",
+	} {
+		if err := os.Unsetenv(env); err != nil {
+			panic(err)
+		}
+	}
+
+	os.Exit(m.Run())
+}
+
 func TestTag(t *testing.T) {
 	tests := map[string]struct {
 		CurrentBranch string
@@ -53,6 +79,7 @@ func TestTag(t *testing.T) {
 				AncestorTag:  "e63c125b",
 				SemverTag:    "v1.0.0",
 				IsPrerelease: false,
+				LatestTag:    "1.0.0-pre.1",
 			},
 		},
 		"doc branch into develop": {
@@ -71,6 +98,7 @@ func TestTag(t *testing.T) {
 				AncestorTag:  "v0.2.0-pre.1",
 				SemverTag:    "v0.2.1-pre.2",
 				IsPrerelease: true,
+				LatestTag:    "v0.2.1-pre.1",
 			},
 		},
 		"doc branch into develop when latest tag is equal to ancestor develop tag excluding prerelease part": {
@@ -92,6 +120,7 @@ func TestTag(t *testing.T) {
 				AncestorTag:  "v0.2.1-pre.2",
 				SemverTag:    "v0.2.1-pre.3",
 				IsPrerelease: true,
+				LatestTag:    "v0.2.1",
 			},
 		},
 		"misc branch into develop": {
@@ -110,6 +139,7 @@ func TestTag(t *testing.T) {
 				AncestorTag:  "v0.2.0-pre.1",
 				SemverTag:    "v0.2.1-pre.2",
 				IsPrerelease: true,
+				LatestTag:    "v0.2.1-pre.1",
 			},
 		},
 		"upstream misc branch into develop": {
@@ -128,6 +158,7 @@ func TestTag(t *testing.T) {
 				AncestorTag:  "v0.2.0-pre.1",
 				SemverTag:    "v0.2.1-pre.2",
 				IsPrerelease: true,
+				LatestTag:    "v0.2.1-pre.1",
 			},
 		},
 		"feature branch into develop": {
@@ -144,6 +175,7 @@ func TestTag(t *testing.T) {
 				PreviousTag:  "v0.2.1",
 				SemverTag:    "v0.3.0-pre.1",
 				IsPrerelease: true,
+				LatestTag:    "v0.2.1",
 			},
 		},
 		"upstream feature branch into develop": {
@@ -160,6 +192,7 @@ func TestTag(t *testing.T) {
 				PreviousTag:  "v0.2.1",
 				SemverTag:    "v0.3.0-pre.1",
 				IsPrerelease: true,
+				LatestTag:    "v0.2.1",
 			},
 		},
 		"bugfix branch into develop": {
@@ -176,6 +209,7 @@ func TestTag(t *testing.T) {
 				PreviousTag:  "v0.2.1",
 				SemverTag:    "v0.2.2-pre.1",
 				IsPrerelease: true,
+				LatestTag:    "v0.2.1",
 			},
 		},
 		"upstream bugfix branch into develop": {
@@ -192,6 +226,7 @@ func TestTag(t *testing.T) {
 				PreviousTag:  "v0.2.1",
 				SemverTag:    "v0.2.2-pre.1",
 				IsPrerelease: true,
+				LatestTag:    "v0.2.1",
 			},
 		},
 		"hotfix branch into master": {
@@ -208,6 +243,7 @@ func TestTag(t *testing.T) {
 				PreviousTag:  "v0.2.1",
 				SemverTag:    "v0.2.2",
 				IsPrerelease: false,
+				LatestTag:    "v0.2.1",
 			},
 		},
 		"exclude branch": {
@@ -229,6 +265,72 @@ func TestTag(t *testing.T) {
 				IsPrerelease: false,
 			},
 		},
+		"rule without bump": {
+			CurrentBranch: "develop",
+			LatestTag:     "v0.2.1-pre.1",
+			SourceBranch:  "chore/deps",
+			Params: func() generate.Params {
+				p, err := generate.LoadParams()
+				require.NoError(t, err)
+
+				p.Rules = strategy.Rules{{Source: regex.MustCompile(`^chore/.+`)}}
+
+				return p
+			},
+			Result: generate.Result{},
+		},
+		"rule overrides built-in rule": {
+			CurrentBranch: "develop",
+			LatestTag:     "v0.2.1-pre.1",
+			SourceBranch:  "feature/deps",
+			Params: func() generate.Params {
+				p, err := generate.LoadParams()
+				require.NoError(t, err)
+
+				p.Rules = strategy.Rules{{Source: regex.MustCompile(`^feature/deps$`), Method: "build", Version: "patch"}}
+
+				return p
+			},
+			Result: generate.Result{
+				PreviousTag:  "v0.2.1-pre.1",
+				SemverTag:    "v0.2.2-pre.1",
+				IsPrerelease: true,
+				LatestTag:    "v0.2.1-pre.1",
+			},
+		},
+		"skip label": {
+			CurrentBranch: "develop",
+			LatestTag:     "v0.2.1-pre.1",
+			SourceBranch:  "feature/some",
+			Params: func() generate.Params {
+				p, err := generate.LoadParams()
+				require.NoError(t, err)
+
+				p.Labels = []string{"semver:skip"}
+
+				return p
+			},
+			Result: generate.Result{},
+		},
+		"label overrides branch pattern": {
+			CurrentBranch: "develop",
+			LatestTag:     "v0.2.1-pre.1",
+			SourceBranch:  "bugfix/some",
+			Params: func() generate.Params {
+				p, err := generate.LoadParams()
+				require.NoError(t, err)
+
+				p.Labels = []string{"semver:minor"}
+
+				return p
+			},
+			Result: generate.Result{
+				PreviousTag:  "v0.2.1-pre.1",
+				SemverTag:    "v0.3.0-pre.1",
+				IsPrerelease: true,
+				LatestTag:    "v0.2.1-pre.1",
+			},
+		},
 		"merge develop into master": {
 			CurrentBranch: "master",
 			LatestTag:     "1.4.17-pre.1",
@@ -243,6 +345,7 @@ func TestTag(t *testing.T) {
 				PreviousTag:  "v1.4.17-pre.1",
 				SemverTag:    "v1.4.17",
 				IsPrerelease: false,
+				LatestTag:    "1.4.17-pre.1",
 			},
 		},
 		"merge develop into master with previous matching tag": {
@@ -261,6 +364,7 @@ func TestTag(t *testing.T) {
 				AncestorTag:  "v1.4.16",
 				SemverTag:    "v1.4.17",
 				IsPrerelease: false,
+				LatestTag:    "1.4.17-pre.1",
 			},
 		},
 		"base version set": {
@@ -279,6 +383,7 @@ func TestTag(t *testing.T) {
 				PreviousTag:  "v2.6.19",
 				SemverTag:    "v4.3.0-pre.1",
 				IsPrerelease: true,
+				LatestTag:    "2.6.19",
 			},
 		},
 		"invalid branch name": {
@@ -295,6 +400,7 @@ func TestTag(t *testing.T) {
 				PreviousTag:  "v2.6.19-pre.1",
 				SemverTag:    "v2.6.19-pre.2",
 				IsPrerelease: true,
+				LatestTag:    "2.6.19-pre.1",
 			},
 		},
 		"force bump major": {
@@ -313,6 +419,7 @@ func TestTag(t *testing.T) {
 				PreviousTag:  "v2.6.19-pre.1",
 				SemverTag:    "v3.0.0-pre.1",
 				IsPrerelease: true,
+				LatestTag:    "2.6.19-pre.1",
 			},
 		},
 		"force bump minor": {
@@ -331,6 +438,7 @@ func TestTag(t *testing.T) {
 				PreviousTag:  "v2.6.19-pre.1",
 				SemverTag:    "v2.7.0-pre.1",
 				IsPrerelease: true,
+				LatestTag:    "2.6.19-pre.1",
 			},
 		},
 		"force bump patch": {
@@ -349,6 +457,7 @@ func TestTag(t *testing.T) {
 				PreviousTag:  "v2.6.19-pre.1",
 				SemverTag:    "v2.6.20-pre.1",
 				IsPrerelease: true,
+				LatestTag:    "2.6.19-pre.1",
 			},
 		},
 	}
@@ -369,106 +478,1326 @@ func TestTag(t *testing.T) {
 			result, err := generate.Tag(p, gc)
 			require.NoError(t, err)
 
+			require.NotNil(t, result.Trace)
+			assert.Equal(t, result.SemverTag, result.Trace.SemverTag)
+
+			result.Trace = nil
+
 			assert.Equal(t, test.Result, result)
 		})
 	}
 }
 
-func TestTag_IsNotRepo(t *testing.T) {
-	gc := &gitClientMock{
-		MakeSafeFn: func() error {
-			return nil
+func TestTag_Event(t *testing.T) {
+	tests := map[string]struct {
+		Event        *actions.Event
+		HeadRef      string
+		BaseRef      string
+		SourceBranch string
+		Result       generate.Result
+	}{
+		"merged pull request": {
+			Event: &actions.Event{
+				Name: "pull_request",
+				PullRequest: &actions.PullRequest{
+					Number: 12,
+					Merged: true,
+					Head:   actions.Branch{Ref: "feature/some"},
+					Base:   actions.Branch{Ref: "develop"},
+				},
+			},
+			Result: generate.Result{
+				PreviousTag:  "v0.2.1",
+				SemverTag:    "v0.3.0-pre.1",
+				IsPrerelease: true,
+				LatestTag:    "v0.2.1",
+			},
 		},
-		IsRepoFn: func() bool {
-			return false
+		"merged pull request into master": {
+			Event: &actions.Event{
+				Name: "pull_request_target",
+				PullRequest: &actions.PullRequest{
+					Number: 13,
+					Merged: true,
+					Head:   actions.Branch{Ref: "hotfix/some"},
+					Base:   actions.Branch{Ref: "master"},
+				},
+			},
+			Result: generate.Result{
+				PreviousTag:  "v0.2.1",
+				SemverTag:    "v0.2.2",
+				IsPrerelease: false,
+				LatestTag:    "v0.2.1",
+			},
+		},
+		"unmerged pull request": {
+			Event: &actions.Event{
+				Name: "pull_request",
+				PullRequest: &actions.PullRequest{
+					Number: 14,
+					Head:   actions.Branch{Ref: "feature/some"},
+					Base:   actions.Branch{Ref: "develop"},
+				},
+			},
+		},
+		"head and base refs without payload": {
+			HeadRef: "bugfix/some",
+			BaseRef: "develop",
+			Result: generate.Result{
+				PreviousTag:  "v0.2.1",
+				SemverTag:    "v0.2.2-pre.1",
+				IsPrerelease: true,
+				LatestTag:    "v0.2.1",
+			},
+		},
+		"push with merge commit": {
+			Event: &actions.Event{
+				Name: "push",
+				Ref:  "refs/heads/develop",
+			},
+			SourceBranch: "feature/some",
+			Result: generate.Result{
+				PreviousTag:  "v0.2.1",
+				SemverTag:    "v0.3.0-pre.1",
+				IsPrerelease: true,
+				LatestTag:    "v0.2.1",
+			},
+		},
+		"push with squash commit": {
+			Event: &actions.Event{
+				Name: "push",
+				Ref:  "refs/heads/develop",
+			},
+			Result: generate.Result{
+				PreviousTag:  "v0.2.1",
+				SemverTag:    "v0.2.1-pre.1",
+				IsPrerelease: true,
+				LatestTag:    "v0.2.1",
+			},
 		},
 	}
 
-	_, err := generate.Tag(generate.Params{}, gc)
-	require.Error(t, err)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := generate.LoadParams()
+			require.NoError(t, err)
 
-	assert.EqualError(t, err, "current folder is not a git repository")
+			p.Event = test.Event
+			p.HeadRef = test.HeadRef
+			p.BaseRef = test.BaseRef
+
+			gc := initGitClientMock(t, "v0.2.1", "", "", "", p.CommitSha)
+			gc.CurrentBranchFn = func() (string, error) {
+				return "", errors.New("detached head")
+			}
+			gc.SourceBranchFn = func(commitHash string) (string, error) {
+				if test.SourceBranch == "" {
+					return "", errors.New("no source branch found")
+				}
+
+				return test.SourceBranch, nil
+			}
+
+			result, err := generate.Tag(p, gc)
+			require.NoError(t, err)
+
+			require.NotNil(t, result.Trace)
+			assert.Equal(t, result.SemverTag, result.Trace.SemverTag)
+
+			result.Trace = nil
+
+			assert.Equal(t, test.Result, result)
+			assert.Zero(t, gc.CurrentBranchFnInvoked)
+		})
+	}
 }
 
-func TestTag_MakeSafeErr(t *testing.T) {
-	gc := &gitClientMock{
-		MakeSafeFn: func() error {
-			return errors.New("error")
+func TestTag_GitHubFlow(t *testing.T) {
+	tests := map[string]struct {
+		Event  *actions.Event
+		Ref    string
+		Result generate.Result
+	}{
+		"open pull request": {
+			Event: &actions.Event{
+				Name: "pull_request",
+				PullRequest: &actions.PullRequest{
+					Number: 12,
+					Head:   actions.Branch{Ref: "feature/some"},
+					Base:   actions.Branch{Ref: "main"},
+				},
+			},
+			Result: generate.Result{
+				PreviousTag:  "v0.2.1",
+				SemverTag:    "v0.3.0-pr.12.3",
+				IsPrerelease: true,
+				LatestTag:    "v0.2.1",
+			},
+		},
+		"merged pull request": {
+			Event: &actions.Event{
+				Name: "pull_request",
+				PullRequest: &actions.PullRequest{
+					Number: 12,
+					Merged: true,
+					Head:   actions.Branch{Ref: "feature/some"},
+					Base:   actions.Branch{Ref: "main"},
+				},
+			},
+			Result: generate.Result{
+				PreviousTag:  "v0.2.1",
+				SemverTag:    "v0.3.0",
+				IsPrerelease: false,
+				LatestTag:    "v0.2.1",
+			},
+		},
+		"pull request ref without payload": {
+			Ref: "refs/pull/12/merge",
+			Result: generate.Result{
+				PreviousTag:  "v0.2.1",
+				SemverTag:    "v0.3.0-pr.12.3",
+				IsPrerelease: true,
+				LatestTag:    "v0.2.1",
+			},
 		},
 	}
 
-	_, err := generate.Tag(generate.Params{}, gc)
-	require.Error(t, err)
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := generate.LoadParams()
+			require.NoError(t, err)
 
-	assert.EqualError(t, err, "failed to make safe: error")
-}
+			p.BranchingModel = "github-flow"
+			p.MainBranchName = "main"
+			p.Event = test.Event
+			p.Ref = test.Ref
 
-type gitClientMock struct {
-	CurrentBranchFn        func() (string, error)
-	CurrentBranchFnInvoked int
-	IsRepoFn               func() bool
-	IsRepoFnInvoked        int
-	MakeSafeFn             func() error
-	MakeSafeFnInvoked      int
-	LatestTagFn            func(include, exclude string) string
-	LatestTagFnInvoked     int
-	AncestorTagFn          func(include, exclude, branch string) string
-	AncestorTagFnInvoked   int
-	SourceBranchFn         func(commitHash string) (string, error)
-	SourceBranchFnInvoked  int
+			gc := initGitClientMock(t, "v0.2.1", "", "main", "feature/some", p.CommitSha)
+			gc.LatestTagFn = func(include, exclude string) string {
+				assert.Equal(t, "v*-pr.*", exclude)
+
+				return "v0.2.1"
+			}
+			gc.TagsFn = func(pattern string) ([]string, error) {
+				assert.Equal(t, "v0.3.0-pr.12.*", pattern)
+
+				return []string{"v0.3.0-pr.12.1", "v0.3.0-pr.12.2"}, nil
+			}
+
+			result, err := generate.Tag(p, gc)
+			require.NoError(t, err)
+
+			result.Trace = nil
+
+			assert.Equal(t, test.Result, result)
+		})
+	}
 }
 
-func initGitClientMock(t *testing.T, latestTag, ancestorTag, currentBranch, sourceBranch, expectedCommitHash string) *gitClientMock {
-	return &gitClientMock{
-		CurrentBranchFn: func() (string, error) {
-			return currentBranch, nil
+func TestTag_Maintenance(t *testing.T) {
+	tests := map[string]struct {
+		DestBranch      string
+		LatestTag       string
+		ExpectedInclude string
+		Result          generate.Result
+	}{
+		"minor line": {
+			DestBranch:      "release/1.4",
+			LatestTag:       "v1.4.2",
+			ExpectedInclude: "v1.4.*",
+			Result: generate.Result{
+				PreviousTag: "v1.4.2",
+				SemverTag:   "v1.4.3",
+				LatestTag:   "v1.4.2",
+			},
 		},
-		IsRepoFn: func() bool {
-			return true
+		"major line": {
+			DestBranch:      "release/1.x",
+			LatestTag:       "v1.7.0",
+			ExpectedInclude: "v1.*",
+			Result: generate.Result{
+				PreviousTag: "v1.7.0",
+				SemverTag:   "v1.7.1",
+				LatestTag:   "v1.7.0",
+			},
 		},
-		MakeSafeFn: func() error {
-			return nil
+		"no tag in line": {
+			DestBranch:      "release/2.1",
+			ExpectedInclude: "v2.1.*",
+			Result: generate.Result{
+				PreviousTag: "v2.1.0",
+				SemverTag:   "v2.1.1",
+			},
 		},
-		LatestTagFn: func(include, exclude string) string {
-			return latestTag
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := generate.LoadParams()
+			require.NoError(t, err)
+
+			p.BranchingModel = "trunk-based"
+			p.MainBranchName = "master"
+
+			gc := initGitClientMock(t, "", "", test.DestBranch, "feature/some", p.CommitSha)
+			gc.LatestTagFn = func(include, exclude string) string {
+				assert.Equal(t, test.ExpectedInclude, include)
+
+				return test.LatestTag
+			}
+
+			result, err := generate.Tag(p, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, "dest branch matches maintenance pattern", result.Trace.Decision)
+
+			result.Trace = nil
+
+			assert.Equal(t, test.Result, result)
+		})
+	}
+}
+
+func TestTag_PrereleaseChannels(t *testing.T) {
+	tests := map[string]struct {
+		DestBranch string
+		LatestTag  string
+		Result     generate.Result
+	}{
+		"develop continues alpha": {
+			DestBranch: "develop",
+			LatestTag:  "v1.3.0-alpha.5",
+			Result: generate.Result{
+				PreviousTag:  "v1.3.0-alpha.5",
+				SemverTag:    "v1.3.0-alpha.6",
+				IsPrerelease: true,
+				LatestTag:    "v1.3.0-alpha.5",
+			},
 		},
-		AncestorTagFn: func(include, exclude, branch string) string {
-			return ancestorTag
+		"release promotes alpha to beta": {
+			DestBranch: "release/1.3",
+			LatestTag:  "v1.3.0-alpha.5",
+			Result: generate.Result{
+				PreviousTag:  "v1.3.0-alpha.5",
+				SemverTag:    "v1.3.0-beta.1",
+				IsPrerelease: true,
+				LatestTag:    "v1.3.0-alpha.5",
+			},
 		},
-		SourceBranchFn: func(commitHash string) (string, error) {
-			assert.Equal(t, expectedCommitHash, commitHash)
-			return sourceBranch, nil
+		"release continues beta": {
+			DestBranch: "release/1.3",
+			LatestTag:  "v1.3.0-beta.2",
+			Result: generate.Result{
+				PreviousTag:  "v1.3.0-beta.2",
+				SemverTag:    "v1.3.0-beta.3",
+				IsPrerelease: true,
+				LatestTag:    "v1.3.0-beta.2",
+			},
+		},
+		"staging promotes beta to rc": {
+			DestBranch: "staging",
+			LatestTag:  "v1.3.0-beta.3",
+			Result: generate.Result{
+				PreviousTag:  "v1.3.0-beta.3",
+				SemverTag:    "v1.3.0-rc.1",
+				IsPrerelease: true,
+				LatestTag:    "v1.3.0-beta.3",
+			},
+		},
+		"unmapped branch uses prerelease id": {
+			DestBranch: "sandbox",
+			LatestTag:  "v1.3.0-rc.1",
+			Result: generate.Result{
+				PreviousTag:  "v1.3.0-rc.1",
+				SemverTag:    "v1.3.0-pre.1",
+				IsPrerelease: true,
+				LatestTag:    "v1.3.0-rc.1",
+			},
 		},
 	}
-}
 
-func (m *gitClientMock) CurrentBranch() (string, error) {
-	m.CurrentBranchFnInvoked += 1
-	return m.CurrentBranchFn()
-}
-func (m *gitClientMock) IsRepo() bool {
-	m.IsRepoFnInvoked += 1
-	return m.IsRepoFn()
-}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := generate.LoadParams()
+			require.NoError(t, err)
 
-func (m *gitClientMock) MakeSafe() error {
-	m.MakeSafeFnInvoked++
-	return m.MakeSafeFn()
-}
+			p.PrereleaseChannels = []generate.PrereleaseChannel{
+				{Branch: regex.MustCompile(`^develop$`), PrereleaseID: "alpha"},
+				{Branch: regex.MustCompile(`^release/.+`), PrereleaseID: "beta"},
+				{Branch: regex.MustCompile(`^staging$`), PrereleaseID: "rc"},
+			}
 
-func (m *gitClientMock) LatestTag(include, exclude string) string {
-	m.LatestTagFnInvoked += 1
-	return m.LatestTagFn(include, exclude)
-}
+			gc := initGitClientMock(t, test.LatestTag, "", test.DestBranch, "some-branch", p.CommitSha)
 
-func (m *gitClientMock) AncestorTag(include, exclude, branch string) string {
-	m.AncestorTagFnInvoked += 1
-	return m.AncestorTagFn(include, exclude, branch)
+			result, err := generate.Tag(p, gc)
+			require.NoError(t, err)
+
+			result.Trace = nil
+
+			assert.Equal(t, test.Result, result)
+		})
+	}
 }
 
-func (m *gitClientMock) SourceBranch(commitHash string) (string, error) {
-	m.SourceBranchFnInvoked += 1
-	return m.SourceBranchFn(commitHash)
+func TestTag_InitialDevelopment(t *testing.T) {
+	tests := map[string]struct {
+		Bump      string
+		LatestTag string
+		Result    generate.Result
+	}{
+		"no previous tag": {
+			Bump: "auto",
+			Result: generate.Result{
+				PreviousTag: "v0.0.0",
+				SemverTag:   "v0.1.0",
+			},
+		},
+		"breaking change bumps minor": {
+			Bump:      "auto",
+			LatestTag: "v0.4.2",
+			Result: generate.Result{
+				PreviousTag: "v0.4.2",
+				SemverTag:   "v0.5.0",
+				LatestTag:   "v0.4.2",
+			},
+		},
+		"graduate": {
+			Bump:      "graduate",
+			LatestTag: "v0.4.2",
+			Result: generate.Result{
+				PreviousTag: "v0.4.2",
+				SemverTag:   "v1.0.0",
+				LatestTag:   "v0.4.2",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := generate.LoadParams()
+			require.NoError(t, err)
+
+			p.Bump = test.Bump
+			p.BranchingModel = "trunk-based"
+			p.InitialDevelopment = true
+			p.MainBranchName = "master"
+
+			gc := initGitClientMock(t, test.LatestTag, "", "master", "release/semver-initial", p.CommitSha)
+
+			result, err := generate.Tag(p, gc)
+			require.NoError(t, err)
+
+			result.Trace = nil
+
+			assert.Equal(t, test.Result, result)
+		})
+	}
+}
+
+func TestTag_Collision(t *testing.T) {
+	tests := map[string]struct {
+		BranchingModel string
+		CurrentBranch  string
+		SourceBranch   string
+		LatestTag      string
+		ExistingTags   []string
+		ExpectedNotes  []string
+		Result         generate.Result
+	}{
+		"prerelease advances counter": {
+			BranchingModel: "git-flow",
+			CurrentBranch:  "develop",
+			SourceBranch:   "some-branch",
+			LatestTag:      "v1.3.0-pre.2",
+			ExistingTags:   []string{"v1.3.0-pre.3", "v1.3.0-pre.4"},
+			ExpectedNotes: []string{
+				"tag v1.3.0-pre.3 already exists, advanced to v1.3.0-pre.4",
+				"tag v1.3.0-pre.4 already exists, advanced to v1.3.0-pre.5",
+			},
+			Result: generate.Result{
+				PreviousTag:  "v1.3.0-pre.2",
+				SemverTag:    "v1.3.0-pre.5",
+				IsPrerelease: true,
+				SkippedTags:  []string{"v1.3.0-pre.3", "v1.3.0-pre.4"},
+				LatestTag:    "v1.3.0-pre.2",
+			},
+		},
+		"build advances counter": {
+			BranchingModel: "trunk-based",
+			CurrentBranch:  "master",
+			SourceBranch:   "misc/some",
+			LatestTag:      "v1.2.3+4",
+			ExistingTags:   []string{"v1.2.3+5"},
+			ExpectedNotes: []string{
+				"tag v1.2.3+5 already exists, advanced to v1.2.3+6",
+			},
+			Result: generate.Result{
+				PreviousTag: "v1.2.3+4",
+				SemverTag:   "v1.2.3+6",
+				SkippedTags: []string{"v1.2.3+5"},
+				LatestTag:   "v1.2.3+4",
+			},
+		},
+		"no collision": {
+			BranchingModel: "trunk-based",
+			CurrentBranch:  "master",
+			SourceBranch:   "feature/some",
+			LatestTag:      "v1.2.3",
+			ExistingTags:   []string{"v1.2.3"},
+			Result: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v1.3.0",
+				LatestTag:   "v1.2.3",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := generate.LoadParams()
+			require.NoError(t, err)
+
+			p.BranchingModel = test.BranchingModel
+			p.MainBranchName = "master"
+
+			gc := initGitClientMock(t, test.LatestTag, "", test.CurrentBranch, test.SourceBranch, p.CommitSha)
+			gc.TagExistsFn = func(name string) bool {
+				for _, tag := range test.ExistingTags {
+					if tag == name {
+						return true
+					}
+				}
+
+				return false
+			}
+
+			result, err := generate.Tag(p, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.ExpectedNotes, result.Trace.Notes)
+
+			result.Trace = nil
+
+			assert.Equal(t, test.Result, result)
+		})
+	}
+}
+
+func TestTag_CollisionErr(t *testing.T) {
+	p, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	p.BranchingModel = "trunk-based"
+	p.MainBranchName = "master"
+
+	gc := initGitClientMock(t, "v1.2.3", "", "master", "feature/some", p.CommitSha)
+	gc.TagExistsFn = func(name string) bool {
+		return name == "v1.3.0"
+	}
+
+	_, err = generate.Tag(p, gc)
+	require.Error(t, err)
+
+	assert.Equal(t, "failed to tag: tag v1.3.0 already exists", err.Error())
+}
+
+func TestTag_Trailers(t *testing.T) {
+	tests := map[string]struct {
+		SourceBranch     string
+		LatestTag        string
+		Trailers         []git.Trailer
+		ExpectedDecision string
+		Result           generate.Result
+	}{
+		"semver skip": {
+			SourceBranch:     "feature/some",
+			LatestTag:        "v1.2.3",
+			Trailers:         []git.Trailer{{Key: "Semver", Value: "skip"}},
+			ExpectedDecision: "commit trailer skips the bump",
+		},
+		"semver bump overrides branch pattern": {
+			SourceBranch:     "bugfix/some",
+			LatestTag:        "v1.2.3",
+			Trailers:         []git.Trailer{{Key: "semver-bump", Value: "Minor"}},
+			ExpectedDecision: "bump is minor",
+			Result: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v1.3.0",
+				LatestTag:   "v1.2.3",
+			},
+		},
+		"last trailer wins": {
+			SourceBranch: "feature/some",
+			LatestTag:    "v1.2.3",
+			Trailers: []git.Trailer{
+				{Key: "Semver-Bump", Value: "major"},
+				{Key: "Signed-off-by", Value: "John Doe <john@example.com>"},
+				{Key: "Semver-Bump", Value: "patch"},
+			},
+			ExpectedDecision: "bump is patch",
+			Result: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v1.2.4",
+				LatestTag:   "v1.2.3",
+			},
+		},
+		"release as": {
+			SourceBranch:     "feature/some",
+			LatestTag:        "v1.2.3",
+			Trailers:         []git.Trailer{{Key: "Release-As", Value: "2.0.0"}},
+			ExpectedDecision: "commit trailer releases as 2.0.0",
+			Result: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v2.0.0",
+				LatestTag:   "v1.2.3",
+			},
+		},
+		"release as prerelease without tags": {
+			SourceBranch:     "feature/some",
+			Trailers:         []git.Trailer{{Key: "Release-As", Value: "v1.0.0-rc.1"}},
+			ExpectedDecision: "commit trailer releases as 1.0.0-rc.1",
+			Result: generate.Result{
+				PreviousTag:  "v0.0.0",
+				SemverTag:    "v1.0.0-rc.1",
+				IsPrerelease: true,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := generate.LoadParams()
+			require.NoError(t, err)
+
+			p.BranchingModel = "trunk-based"
+			p.MainBranchName = "master"
+
+			gc := initGitClientMock(t, test.LatestTag, "", "master", test.SourceBranch, p.CommitSha)
+			gc.TrailersFn = func(commitHash string) ([]git.Trailer, error) {
+				assert.Equal(t, p.CommitSha, commitHash)
+
+				return test.Trailers, nil
+			}
+
+			result, err := generate.Tag(p, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.ExpectedDecision, result.Trace.Decision)
+
+			result.Trace = nil
+
+			assert.Equal(t, test.Result, result)
+			assert.Equal(t, 1, gc.TrailersFnInvoked)
+		})
+	}
+}
+
+func TestTag_TrailersErr(t *testing.T) {
+	tests := map[string]struct {
+		LatestTag   string
+		Trailers    []git.Trailer
+		TrailersErr error
+		ExistingTag string
+		ExpectedErr string
+	}{
+		"git error": {
+			TrailersErr: errors.New("bad object"),
+			ExpectedErr: "failed to read commit trailers: bad object",
+		},
+		"invalid semver bump": {
+			Trailers:    []git.Trailer{{Key: "Semver-Bump", Value: "final"}},
+			ExpectedErr: "invalid Semver-Bump trailer: final",
+		},
+		"invalid release as": {
+			Trailers:    []git.Trailer{{Key: "Release-As", Value: "next"}},
+			ExpectedErr: "invalid Release-As trailer: next",
+		},
+		"release as not greater than latest tag": {
+			LatestTag:   "v2.0.0",
+			Trailers:    []git.Trailer{{Key: "Release-As", Value: "2.0.0"}},
+			ExpectedErr: "failed to tag: release as 2.0.0 must be greater than the latest tag 2.0.0",
+		},
+		"release as already exists": {
+			LatestTag:   "v1.2.3",
+			Trailers:    []git.Trailer{{Key: "Release-As", Value: "2.0.0"}},
+			ExistingTag: "v2.0.0",
+			ExpectedErr: "failed to tag: tag v2.0.0 already exists",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := generate.LoadParams()
+			require.NoError(t, err)
+
+			p.BranchingModel = "trunk-based"
+			p.MainBranchName = "master"
+
+			gc := initGitClientMock(t, test.LatestTag, "", "master", "feature/some", p.CommitSha)
+			gc.TrailersFn = func(commitHash string) ([]git.Trailer, error) {
+				return test.Trailers, test.TrailersErr
+			}
+			gc.TagExistsFn = func(name string) bool {
+				return name == test.ExistingTag
+			}
+
+			_, err = generate.Tag(p, gc)
+			require.Error(t, err)
+
+			assert.Equal(t, test.ExpectedErr, err.Error())
+		})
+	}
+}
+
+func TestTag_Paths(t *testing.T) {
+	tests := map[string]struct {
+		BranchingModel       string
+		Bump                 string
+		SourceBranch         string
+		DestBranch           string
+		IncludePaths         []glob.Glob
+		ExcludePaths         []glob.Glob
+		UnmatchedPaths       string
+		ChangedFiles         []string
+		ExpectedChangedFiles int
+		ExpectedNote         string
+		Result               generate.Result
+	}{
+		"no paths": {
+			BranchingModel: "trunk-based",
+			ChangedFiles:   []string{"docs/index.md"},
+			Result: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v1.3.0",
+				LatestTag:   "v1.2.3",
+			},
+		},
+		"relevant files changed": {
+			BranchingModel:       "trunk-based",
+			IncludePaths:         []glob.Glob{glob.MustCompile("cmd/")},
+			ChangedFiles:         []string{"README.md", "cmd/main.go"},
+			ExpectedChangedFiles: 1,
+			Result: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v1.3.0",
+				LatestTag:   "v1.2.3",
+			},
+		},
+		"every file excluded": {
+			BranchingModel:       "trunk-based",
+			ExcludePaths:         []glob.Glob{glob.MustCompile("docs/"), glob.MustCompile("**/*_test.go")},
+			ChangedFiles:         []string{"docs/index.md", "pkg/lib_test.go"},
+			ExpectedChangedFiles: 1,
+			ExpectedNote:         "none of 2 changed files is relevant since latest tag",
+		},
+		"no included file": {
+			BranchingModel:       "trunk-based",
+			IncludePaths:         []glob.Glob{glob.MustCompile("cmd/")},
+			ChangedFiles:         []string{"README.md"},
+			ExpectedChangedFiles: 1,
+			ExpectedNote:         "none of 1 changed files is relevant since latest tag",
+		},
+		"downgrade to build": {
+			BranchingModel:       "trunk-based",
+			UnmatchedPaths:       "build",
+			ExcludePaths:         []glob.Glob{glob.MustCompile("docs/"), glob.MustCompile("**/*_test.go")},
+			ChangedFiles:         []string{"docs/index.md"},
+			ExpectedChangedFiles: 1,
+			ExpectedNote:         "bump downgraded from minor to build",
+			Result: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v1.2.3+1",
+				LatestTag:   "v1.2.3",
+			},
+		},
+		"github-flow has no build bump": {
+			BranchingModel:       "github-flow",
+			UnmatchedPaths:       "build",
+			ExcludePaths:         []glob.Glob{glob.MustCompile("docs/"), glob.MustCompile("**/*_test.go")},
+			ChangedFiles:         []string{"docs/index.md"},
+			ExpectedChangedFiles: 1,
+			ExpectedNote:         "github-flow model has no build bump, skipping",
+		},
+		"git-flow has no build bump into main branch": {
+			BranchingModel:       "git-flow",
+			SourceBranch:         "hotfix/some",
+			UnmatchedPaths:       "build",
+			ExcludePaths:         []glob.Glob{glob.MustCompile("docs/")},
+			ChangedFiles:         []string{"docs/index.md"},
+			ExpectedChangedFiles: 1,
+			ExpectedNote:         "git-flow model has no build bump into master, skipping",
+		},
+		"git-flow downgrade to build into develop branch": {
+			BranchingModel:       "git-flow",
+			DestBranch:           "develop",
+			UnmatchedPaths:       "build",
+			ExcludePaths:         []glob.Glob{glob.MustCompile("docs/")},
+			ChangedFiles:         []string{"docs/index.md"},
+			ExpectedChangedFiles: 1,
+			ExpectedNote:         "bump downgraded from minor to build",
+			Result: generate.Result{
+				PreviousTag:  "v1.2.3",
+				SemverTag:    "v1.2.3-pre.1",
+				IsPrerelease: true,
+				LatestTag:    "v1.2.3",
+			},
+		},
+		"bump is not auto": {
+			BranchingModel: "trunk-based",
+			Bump:           "minor",
+			ExcludePaths:   []glob.Glob{glob.MustCompile("docs/")},
+			ChangedFiles:   []string{"docs/index.md"},
+			Result: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v1.3.0",
+				LatestTag:   "v1.2.3",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := generate.LoadParams()
+			require.NoError(t, err)
+
+			p.BranchingModel = test.BranchingModel
+			p.MainBranchName = "master"
+			p.IncludePaths = test.IncludePaths
+			p.ExcludePaths = test.ExcludePaths
+
+			if test.Bump != "" {
+				p.Bump = test.Bump
+			}
+
+			if test.UnmatchedPaths != "" {
+				p.UnmatchedPaths = test.UnmatchedPaths
+			}
+
+			source, dest := "feature/some", "master"
+
+			if test.SourceBranch != "" {
+				source = test.SourceBranch
+			}
+
+			if test.DestBranch != "" {
+				dest = test.DestBranch
+			}
+
+			gc := initGitClientMock(t, "v1.2.3", "", dest, source, p.CommitSha)
+			gc.ChangedFilesFn = func(from, to string) ([]string, error) {
+				assert.Equal(t, "v1.2.3", from)
+				assert.Equal(t, p.CommitSha, to)
+
+				return test.ChangedFiles, nil
+			}
+
+			result, err := generate.Tag(p, gc)
+			require.NoError(t, err)
+
+			if test.ExpectedNote != "" {
+				assert.Contains(t, result.Trace.Notes, test.ExpectedNote)
+			}
+
+			result.Trace = nil
+
+			assert.Equal(t, test.Result, result)
+			assert.Equal(t, test.ExpectedChangedFiles, gc.ChangedFilesFnInvoked)
+		})
+	}
+}
+
+func TestTag_PathsErr(t *testing.T) {
+	p, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	p.BranchingModel = "trunk-based"
+	p.MainBranchName = "master"
+	p.ExcludePaths = []glob.Glob{glob.MustCompile("docs/")}
+
+	gc := initGitClientMock(t, "v1.2.3", "", "master", "feature/some", p.CommitSha)
+	gc.ChangedFilesFn = func(from, to string) ([]string, error) {
+		return nil, errors.New("bad revision")
+	}
+
+	_, err = generate.Tag(p, gc)
+	require.Error(t, err)
+
+	assert.EqualError(t, err, "failed to get changed files since latest tag: bad revision")
+}
+
+func TestTag_APIDiff(t *testing.T) {
+	tests := map[string]struct {
+		APIDiff          string
+		SourceBranch     string
+		Previous         string
+		Current          string
+		ExpectedWorktree int
+		Result           generate.Result
+	}{
+		"off": {
+			APIDiff:      "off",
+			SourceBranch: "bugfix/some",
+			Previous:     "func Close() {}",
+			Current:      "",
+			Result: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v1.2.4",
+				LatestTag:   "v1.2.3",
+			},
+		},
+		"no changes": {
+			APIDiff:          "bump",
+			SourceBranch:     "bugfix/some",
+			Previous:         "func Close() {}",
+			Current:          "func Close() {}",
+			ExpectedWorktree: 2,
+			Result: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v1.2.4",
+				LatestTag:   "v1.2.3",
+			},
+		},
+		"addition raises patch to minor": {
+			APIDiff:          "bump",
+			SourceBranch:     "bugfix/some",
+			Previous:         "func Close() {}",
+			Current:          "func Close() {}\nfunc Open() {}",
+			ExpectedWorktree: 2,
+			Result: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v1.3.0",
+				LatestTag:   "v1.2.3",
+			},
+		},
+		"removal raises minor to major": {
+			APIDiff:          "bump",
+			SourceBranch:     "feature/some",
+			Previous:         "func Close() {}",
+			Current:          "",
+			ExpectedWorktree: 2,
+			Result: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v2.0.0",
+				LatestTag:   "v1.2.3",
+			},
+		},
+		"no bump without changes": {
+			APIDiff:          "verify",
+			SourceBranch:     "chore/some",
+			Previous:         "func Close() {}",
+			Current:          "func Close() {}",
+			ExpectedWorktree: 2,
+			Result:           generate.Result{},
+		},
+		"addition keeps minor": {
+			APIDiff:          "verify",
+			SourceBranch:     "feature/some",
+			Previous:         "func Close() {}",
+			Current:          "func Close() {}\nfunc Open() {}",
+			ExpectedWorktree: 2,
+			Result: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v1.3.0",
+				LatestTag:   "v1.2.3",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := generate.LoadParams()
+			require.NoError(t, err)
+
+			p.BranchingModel = "trunk-based"
+			p.MainBranchName = "master"
+			p.APIDiff = test.APIDiff
+			p.ExcludePattern = regex.MustCompile(`(?i)^chore/.+`)
+
+			gc := initGitClientMock(t, "v1.2.3", "", "master", test.SourceBranch, p.CommitSha)
+			gc.AddWorktreeFn = func(dir, commitish string) error {
+				if commitish == "v1.2.3" {
+					return writeGoModule(dir, test.Previous)
+				}
+
+				assert.Equal(t, p.CommitSha, commitish)
+
+				return writeGoModule(dir, test.Current)
+			}
+			gc.RemoveWorktreeFn = func(dir string) error {
+				return nil
+			}
+
+			result, err := generate.Tag(p, gc)
+			require.NoError(t, err)
+
+			result.Trace = nil
+
+			assert.Equal(t, test.Result, result)
+			assert.Equal(t, test.ExpectedWorktree, gc.AddWorktreeFnInvoked)
+			assert.Equal(t, test.ExpectedWorktree, gc.RemoveWorktreeFnInvoked)
+		})
+	}
+}
+
+func TestTag_APIDiffErr(t *testing.T) {
+	tests := map[string]struct {
+		SourceBranch string
+		Current      string
+		WorktreeErr  error
+		ExpectedErr  string
+	}{
+		"addition with patch bump": {
+			SourceBranch: "bugfix/some",
+			Current:      "func Close() {}\nfunc Open() {}",
+			ExpectedErr:  "compatible api changes require a minor bump, but the bump is patch",
+		},
+		"removal with minor bump": {
+			SourceBranch: "feature/some",
+			Current:      "",
+			ExpectedErr:  "incompatible api changes require a major bump, but the bump is minor",
+		},
+		"addition without bump": {
+			SourceBranch: "chore/some",
+			Current:      "func Close() {}\nfunc Open() {}",
+			ExpectedErr:  "compatible api changes require a minor bump, but there is no bump",
+		},
+		"removal without bump": {
+			SourceBranch: "chore/some",
+			Current:      "",
+			ExpectedErr:  "incompatible api changes require a major bump, but there is no bump",
+		},
+		"worktree error": {
+			SourceBranch: "feature/some",
+			WorktreeErr:  errors.New("invalid reference"),
+			ExpectedErr:  "failed to diff api: invalid reference",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := generate.LoadParams()
+			require.NoError(t, err)
+
+			p.BranchingModel = "trunk-based"
+			p.MainBranchName = "master"
+			p.APIDiff = "verify"
+			p.ExcludePattern = regex.MustCompile(`(?i)^chore/.+`)
+
+			gc := initGitClientMock(t, "v1.2.3", "", "master", test.SourceBranch, p.CommitSha)
+			gc.AddWorktreeFn = func(dir, commitish string) error {
+				if test.WorktreeErr != nil {
+					return test.WorktreeErr
+				}
+
+				if commitish == "v1.2.3" {
+					return writeGoModule(dir, "func Close() {}")
+				}
+
+				return writeGoModule(dir, test.Current)
+			}
+			gc.RemoveWorktreeFn = func(dir string) error {
+				return nil
+			}
+
+			_, err = generate.Tag(p, gc)
+			require.Error(t, err)
+
+			assert.EqualError(t, err, test.ExpectedErr)
+		})
+	}
+}
+
+// writeGoModule writes a go module with a single package of the declarations.
+func writeGoModule(dir, decls string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module github.com/owner/lib\n"), 0o600); err != nil {
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, "lib.go"), []byte("package lib\n\n"+decls+"\n"), 0o600)
+}
+
+func TestTag_CalVer(t *testing.T) {
+	p, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	p.BranchingModel = "calver"
+	p.CalVerFormat = "YY.0M.MICRO"
+	p.MainBranchName = "main"
+	p.Now = func() time.Time {
+		return time.Date(2024, 6, 18, 10, 0, 0, 0, time.UTC)
+	}
+
+	gc := initGitClientMock(t, "v24.06.4", "", "main", "feature/some", p.CommitSha)
+
+	result, err := generate.Tag(p, gc)
+	require.NoError(t, err)
+
+	require.NotNil(t, result.Trace)
+	assert.Equal(t, "calver", result.Trace.Strategy)
+
+	result.Trace = nil
+
+	assert.Equal(t, generate.Result{
+		PreviousTag: "v24.06.4",
+		SemverTag:   "v24.06.5",
+		LatestTag:   "v24.06.4",
+	}, result)
+}
+
+func TestTag_BumpSourceCommits(t *testing.T) {
+	tests := map[string]struct {
+		CurrentBranch string
+		Commits       []git.Commit
+		Result        generate.Result
+	}{
+		"breaking change into develop": {
+			CurrentBranch: "develop",
+			Commits: []git.Commit{
+				{Hash: "a1", Message: "fix: some bug"},
+				{Hash: "a2", Message: "feat: new api\n\nBREAKING CHANGE: old api removed"},
+			},
+			Result: generate.Result{
+				PreviousTag:  "v1.2.3",
+				SemverTag:    "v2.0.0-pre.1",
+				IsPrerelease: true,
+				LatestTag:    "v1.2.3",
+			},
+		},
+		"feature into master": {
+			CurrentBranch: "master",
+			Commits: []git.Commit{
+				{Hash: "a1", Message: "feat(api): new endpoint"},
+			},
+			Result: generate.Result{
+				PreviousTag:  "v1.2.3",
+				SemverTag:    "v1.3.0",
+				IsPrerelease: false,
+				LatestTag:    "v1.2.3",
+			},
+		},
+		"no conventional commits into master": {
+			CurrentBranch: "master",
+			Commits: []git.Commit{
+				{Hash: "a1", Message: "update readme"},
+			},
+			Result: generate.Result{
+				PreviousTag:  "v1.2.3",
+				SemverTag:    "v1.2.3+1",
+				IsPrerelease: false,
+				LatestTag:    "v1.2.3",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p, err := generate.LoadParams()
+			require.NoError(t, err)
+
+			p.BumpSource = "commits"
+			p.CommitSha = "81918ffc"
+
+			if test.CurrentBranch == "master" {
+				p.BranchingModel = "trunk-based"
+			}
+
+			gc := initGitClientMock(t, "v1.2.3", "", test.CurrentBranch, "some-branch", p.CommitSha)
+			gc.CommitsFn = func(from, to string) ([]git.Commit, error) {
+				assert.Equal(t, "v1.2.3", from)
+				assert.Equal(t, "81918ffc", to)
+
+				return test.Commits, nil
+			}
+
+			result, err := generate.Tag(p, gc)
+			require.NoError(t, err)
+
+			require.NotNil(t, result.Trace)
+			assert.Equal(t, result.SemverTag, result.Trace.SemverTag)
+
+			result.Trace = nil
+
+			assert.Equal(t, test.Result, result)
+			assert.Equal(t, 1, gc.CommitsFnInvoked)
+		})
+	}
+}
+
+func TestTag_IsNotRepo(t *testing.T) {
+	gc := &gitClientMock{
+		MakeSafeFn: func() error {
+			return nil
+		},
+		IsRepoFn: func() bool {
+			return false
+		},
+	}
+
+	_, err := generate.Tag(generate.Params{}, gc)
+	require.Error(t, err)
+
+	assert.EqualError(t, err, "current folder is not a git repository")
+}
+
+func TestTag_MakeSafeErr(t *testing.T) {
+	gc := &gitClientMock{
+		MakeSafeFn: func() error {
+			return errors.New("error")
+		},
+	}
+
+	_, err := generate.Tag(generate.Params{}, gc)
+	require.Error(t, err)
+
+	assert.EqualError(t, err, "failed to make safe: error")
+}
+
+type gitClientMock struct {
+	CurrentBranchFn          func() (string, error)
+	CurrentBranchFnInvoked   int
+	IsRepoFn                 func() bool
+	IsRepoFnInvoked          int
+	MakeSafeFn               func() error
+	MakeSafeFnInvoked        int
+	LatestTagFn              func(include, exclude string) string
+	LatestTagFnInvoked       int
+	AncestorTagFn            func(include, exclude, branch string) string
+	AncestorTagFnInvoked     int
+	SourceBranchFn           func(commitHash string) (string, error)
+	SourceBranchFnInvoked    int
+	TrailersFn               func(commitHash string) ([]git.Trailer, error)
+	TrailersFnInvoked        int
+	CommitsFn                func(from, to string) ([]git.Commit, error)
+	CommitsFnInvoked         int
+	MainlineCommitsFn        func(from, to string) ([]git.Commit, error)
+	MainlineCommitsFnInvoked int
+	ChangedFilesFn           func(from, to string) ([]string, error)
+	ChangedFilesFnInvoked    int
+	TagsFn                   func(pattern string) ([]string, error)
+	TagsFnInvoked            int
+	TagExistsFn              func(name string) bool
+	TagExistsFnInvoked       int
+	CreateTagFn              func(name, commitHash, message string) error
+	CreateTagFnInvoked       int
+	PushTagFn                func(remote, name string) error
+	PushTagFnInvoked         int
+	AddWorktreeFn            func(dir, commitish string) error
+	AddWorktreeFnInvoked     int
+	RemoveWorktreeFn         func(dir string) error
+	RemoveWorktreeFnInvoked  int
+}
+
+func initGitClientMock(t *testing.T, latestTag, ancestorTag, currentBranch, sourceBranch, expectedCommitHash string) *gitClientMock {
+	return &gitClientMock{
+		CurrentBranchFn: func() (string, error) {
+			return currentBranch, nil
+		},
+		IsRepoFn: func() bool {
+			return true
+		},
+		MakeSafeFn: func() error {
+			return nil
+		},
+		LatestTagFn: func(include, exclude string) string {
+			return latestTag
+		},
+		AncestorTagFn: func(include, exclude, branch string) string {
+			return ancestorTag
+		},
+		TagExistsFn: func(name string) bool {
+			return false
+		},
+		SourceBranchFn: func(commitHash string) (string, error) {
+			assert.Equal(t, expectedCommitHash, commitHash)
+			return sourceBranch, nil
+		},
+		TrailersFn: func(commitHash string) ([]git.Trailer, error) {
+			return nil, nil
+		},
+	}
+}
+
+func (m *gitClientMock) CurrentBranch() (string, error) {
+	m.CurrentBranchFnInvoked += 1
+	return m.CurrentBranchFn()
+}
+func (m *gitClientMock) IsRepo() bool {
+	m.IsRepoFnInvoked += 1
+	return m.IsRepoFn()
+}
+
+func (m *gitClientMock) MakeSafe() error {
+	m.MakeSafeFnInvoked++
+	return m.MakeSafeFn()
+}
+
+func (m *gitClientMock) LatestTag(include, exclude string) string {
+	m.LatestTagFnInvoked += 1
+	return m.LatestTagFn(include, exclude)
+}
+
+func (m *gitClientMock) AncestorTag(include, exclude, branch string) string {
+	m.AncestorTagFnInvoked += 1
+	return m.AncestorTagFn(include, exclude, branch)
+}
+
+func (m *gitClientMock) SourceBranch(commitHash string) (string, error) {
+	m.SourceBranchFnInvoked += 1
+	return m.SourceBranchFn(commitHash)
+}
+
+func (m *gitClientMock) Trailers(commitHash string) ([]git.Trailer, error) {
+	m.TrailersFnInvoked += 1
+	return m.TrailersFn(commitHash)
+}
+
+func (m *gitClientMock) Commits(from, to string) ([]git.Commit, error) {
+	m.CommitsFnInvoked += 1
+	return m.CommitsFn(from, to)
+}
+
+func (m *gitClientMock) MainlineCommits(from, to string) ([]git.Commit, error) {
+	m.MainlineCommitsFnInvoked += 1
+	return m.MainlineCommitsFn(from, to)
+}
+
+func (m *gitClientMock) ChangedFiles(from, to string) ([]string, error) {
+	m.ChangedFilesFnInvoked += 1
+	return m.ChangedFilesFn(from, to)
+}
+
+func (m *gitClientMock) Tags(pattern string) ([]string, error) {
+	m.TagsFnInvoked += 1
+	return m.TagsFn(pattern)
+}
+
+func (m *gitClientMock) TagExists(name string) bool {
+	m.TagExistsFnInvoked += 1
+	return m.TagExistsFn(name)
+}
+
+func (m *gitClientMock) CreateTag(name, commitHash, message string) error {
+	m.CreateTagFnInvoked += 1
+	return m.CreateTagFn(name, commitHash, message)
+}
+
+func (m *gitClientMock) PushTag(remote, name string) error {
+	m.PushTagFnInvoked += 1
+	return m.PushTagFn(remote, name)
+}
+
+func (m *gitClientMock) AddWorktree(dir, commitish string) error {
+	m.AddWorktreeFnInvoked += 1
+	return m.AddWorktreeFn(dir, commitish)
+}
+
+func (m *gitClientMock) RemoveWorktree(dir string) error {
+	m.RemoveWorktreeFnInvoked += 1
+	return m.RemoveWorktreeFn(dir)
 }
 
 func newSemVerPtr(t *testing.T, s string) *semver.Version {
@@ -0,0 +1,53 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gandarez/semver-action/internal/gomodule"
+
+	"github.com/apex/log"
+)
+
+// nolint: gochecknoglobals
+var validGoModuleModes = []string{"off", "warn", "error"}
+
+// CheckGoModule checks the module path of go.mod under repo dir and go module
+// dir when the version crosses a major boundary, as v2 and later require the
+// /vN suffix. Depending on the go module mode, a mismatch is logged as a
+// warning or returned as an error.
+func CheckGoModule(params Params, result Result) error {
+	if params.GoModule == "" || params.GoModule == "off" || result.Bump != "major" {
+		return nil
+	}
+
+	err := checkGoModule(params, result.Major)
+	if err == nil {
+		return nil
+	}
+
+	if params.GoModule == "warn" {
+		log.Warnf("go module check failed: %s", err)
+
+		return nil
+	}
+
+	return fmt.Errorf("go module check failed: %s", err)
+}
+
+func checkGoModule(params Params, major uint64) error {
+	fp := filepath.Join(params.RepoDir, params.GoModuleDir, "go.mod")
+
+	data, err := os.ReadFile(fp) // nolint:gosec
+	if err != nil {
+		return fmt.Errorf("failed to read go.mod: %s", err)
+	}
+
+	path, err := gomodule.ModulePath(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse %s: %s", fp, err)
+	}
+
+	return gomodule.CheckMajor(path, major)
+}
@@ -0,0 +1,77 @@
+package generate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gandarez/semver-action/cmd/generate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckGoModule(t *testing.T) {
+	tests := map[string]struct {
+		GoModule    string
+		GoModuleDir string
+		Result      generate.Result
+		Expected    string
+	}{
+		"off": {
+			GoModule: "off",
+			Result:   generate.Result{Major: 2, Bump: "major"},
+		},
+		"not a major bump": {
+			GoModule: "error",
+			Result:   generate.Result{Major: 1, Minor: 3, Bump: "minor"},
+		},
+		"matching suffix": {
+			GoModule:    "error",
+			GoModuleDir: "sub/dir",
+			Result:      generate.Result{Major: 2, Bump: "major"},
+		},
+		"missing suffix": {
+			GoModule: "error",
+			Result:   generate.Result{Major: 2, Bump: "major"},
+			Expected: "go module check failed: module path github.com/owner/lib must end with /v2 for major version 2",
+		},
+		"missing suffix warns": {
+			GoModule: "warn",
+			Result:   generate.Result{Major: 2, Bump: "major"},
+		},
+		"missing go.mod": {
+			GoModule:    "error",
+			GoModuleDir: "other",
+			Result:      generate.Result{Major: 2, Bump: "major"},
+			Expected:    "go module check failed: failed to read go.mod:",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repoDir := t.TempDir()
+
+			require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "sub", "dir"), 0755))
+			require.NoError(t, os.WriteFile(
+				filepath.Join(repoDir, "go.mod"), []byte("module github.com/owner/lib\n\ngo 1.22\n"), 0600))
+			require.NoError(t, os.WriteFile(
+				filepath.Join(repoDir, "sub", "dir", "go.mod"), []byte("module github.com/owner/lib/sub/dir/v2\n"), 0600))
+
+			err := generate.CheckGoModule(generate.Params{
+				RepoDir:     repoDir,
+				GoModule:    test.GoModule,
+				GoModuleDir: test.GoModuleDir,
+			}, test.Result)
+
+			if test.Expected == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+
+			assert.Contains(t, err.Error(), test.Expected)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// parseLabelBumps parses the JSON object mapping pull request labels to
+// major, minor, patch or skip.
+func parseLabelBumps(data string) (map[string]string, error) {
+	var labelBumps map[string]string
+
+	if err := json.Unmarshal([]byte(data), &labelBumps); err != nil {
+		return nil, err
+	}
+
+	for label, bump := range labelBumps {
+		if !stringInSlice(bump, validLabelBumps) {
+			return nil, fmt.Errorf("%s: %s", label, bump)
+		}
+	}
+
+	return labelBumps, nil
+}
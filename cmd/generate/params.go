@@ -1,13 +1,22 @@
 package generate
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"regexp"
 	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
-	"github.com/gandarez/semver-action/internal/regex"
+	"github.com/gandarez/semver-action/internal/dockertag"
+	"github.com/gandarez/semver-action/internal/glob"
 	"github.com/gandarez/semver-action/pkg/actions"
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/regex"
+	"github.com/gandarez/semver-action/pkg/strategy"
 
 	"github.com/blang/semver/v4"
 )
@@ -19,38 +28,109 @@ var (
 	branchMajorPrefixRegex   = regex.MustCompile(`(?i)^(.+:)?(release/.+)`)
 	branchBuildPatternRegex  = regex.MustCompile(`(?i)^(.+:)?((doc(s)?|misc)/.+)`)
 	branchHotfixPatternRegex = regex.MustCompile(`(?i)^(.+:)?(hotfix/.+)`)
+	branchMaintenanceRegex   = regex.MustCompile(`(?i)^release/(\d+)\.(\d+|x)$`)
 	commitShaRegex           = regex.MustCompile(`\b[0-9a-f]{5,40}\b`)
-	validBumpStrategies      = []string{"auto", "major", "minor", "patch"}
-	validBranchingModels     = []string{"git-flow", "trunk-based"}
+	validBumpStrategies      = []string{"auto", "major", "minor", "patch", "graduate"}
+	validBumpSources         = []string{"branch", "commits"}
+	validDockerTagSeparators = map[string]string{"newline": "\n", "comma": ","}
+	validLabelBumps          = []string{"major", "minor", "patch", "skip"}
 )
 
 // Params contains semver generate command parameters.
 type Params struct {
-	CommitSha         string
-	RepoDir           string
-	Bump              string
-	BranchingModel    string
-	BaseVersion       *semver.Version
-	Prefix            string
-	PrereleaseID      string
-	MainBranchName    string
-	DevelopBranchName string
+	CommitSha  string
+	RepoDir    string
+	ConfigFile string
+	Bump       string
+	BumpSource string
+	// InitialDevelopment downgrades major to minor and minor to patch while
+	// the major version is zero.
+	InitialDevelopment bool
+	BranchingModel     string
+	MergeFormat        string
+	BaseVersion        *semver.Version
+	Prefix             string
+	PrereleaseID       string
+	// PrereleaseChannels overrides the prerelease id by dest branch.
+	PrereleaseChannels []PrereleaseChannel
+	MainBranchName     string
+	DevelopBranchName  string
 	PatchPattern       regex.Regex
 	MinorPattern       regex.Regex
 	MajorPattern       regex.Regex
 	BuildPattern       regex.Regex
 	HotfixPattern      regex.Regex
 	ExcludePattern     regex.Regex
-	IncludeTagPattern  string
-	ExcludeTagPattern  string
-	Debug              bool
+	// MaintenancePattern matches maintenance branches in trunk-based model. The
+	// first capture group is the major version and the optional second one the minor.
+	MaintenancePattern regex.Regex
+	// Rules are evaluated in order before the built-in rules of the branching
+	// model, and the first match decides the bump.
+	Rules strategy.Rules
+	// Labels are the pull request labels. Defaults to the labels of the
+	// pull request event payload.
+	Labels []string
+	// LabelBumps maps pull request labels to major, minor, patch or skip.
+	LabelBumps map[string]string
+	// CalVerFormat is the version format in calver model, e.g. YYYY.MM.MICRO.
+	CalVerFormat string
+	// StrategyOptions are passed to the strategy of the branching model, for
+	// strategies registered by other packages.
+	StrategyOptions   map[string]string
+	IncludeTagPattern string
+	ExcludeTagPattern string
+	Components        []Component
+	VersionFiles      []VersionFile
+	// GoModule checks the go.mod module path when the major version changes.
+	// Can be off, warn or error.
+	GoModule string
+	// GoModuleDir is the dir of a nested go module relative to repo dir. Its
+	// tags are prefixed by the dir, e.g. sub/dir/v1.2.3.
+	GoModuleDir string
+	// APIDiff compares the exported Go API at the latest tag and at the commit.
+	// Can be off, bump to raise the bump to the one required by the changes,
+	// or verify to fail when the bump is smaller.
+	APIDiff string
+	// IncludePaths and ExcludePaths select the changed files relevant to the
+	// bump. Empty include paths select every file.
+	IncludePaths []glob.Glob
+	ExcludePaths []glob.Glob
+	// UnmatchedPaths is skip or build, the bump when no relevant file changed.
+	UnmatchedPaths string
+	// DockerTags are the kinds of docker tags to render. Empty disables them.
+	DockerTags []dockertag.Kind
+	// DockerImage is prepended to every docker tag, e.g. ghcr.io/owner/app.
+	DockerImage string
+	// DockerTagsSeparator joins the docker tags, a newline or a comma.
+	DockerTagsSeparator string
+	CreateTag           bool
+	PushTag             bool
+	TagMessage          *template.Template
+	Remote              string
+	Changelog           bool
+	ChangelogFile       string
+	Explain             bool
+	Event               *actions.Event
+	Ref                 string
+	HeadRef             string
+	BaseRef             string
+	Debug               bool
+	// Now returns the current time in calver model. Defaults to time.Now in UTC.
+	Now func() time.Time
 }
 
-// LoadParams loads semver generate config params.
+// LoadParams loads semver generate config params from the action inputs.
 func LoadParams() (Params, error) {
+	return LoadParamsWith(actions.GetInput)
+}
+
+// LoadParamsWith loads semver generate config params using input to look up
+// each value by its input name. Commit sha, event name, event path, ref, head
+// ref and base ref fall back to their GITHUB_* environment variables.
+func LoadParamsWith(input func(name string) string) (Params, error) {
 	var commitSha string
 
-	if commitShaStr := os.Getenv("GITHUB_SHA"); commitShaStr != "" {
+	if commitShaStr := inputOrEnv(input, "commit_sha", "GITHUB_SHA"); commitShaStr != "" {
 		if !commitShaRegex.MatchString(commitShaStr) {
 			return Params{}, fmt.Errorf("invalid commit-sha format: %s", commitShaStr)
 		}
@@ -58,15 +138,40 @@ func LoadParams() (Params, error) {
 		commitSha = commitShaStr
 	}
 
+	var event *actions.Event
+
+	if eventPath := inputOrEnv(input, "event_path", "GITHUB_EVENT_PATH"); eventPath != "" {
+		loaded, err := actions.LoadEvent(inputOrEnv(input, "event_name", "GITHUB_EVENT_NAME"), eventPath)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid event: %s", err)
+		}
+
+		event = loaded
+	}
+
 	repoDir := "."
 
-	if repoDirStr := actions.GetInput("repo_dir"); repoDirStr != "" {
+	if repoDirStr := input("repo_dir"); repoDirStr != "" {
 		repoDir = repoDirStr
 	}
 
+	configFile, err := findConfigFile(repoDir, input("config_file"))
+	if err != nil {
+		return Params{}, err
+	}
+
+	if configFile != "" {
+		config, err := loadConfig(configFile)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid config file: %s", err)
+		}
+
+		input = withConfig(input, config)
+	}
+
 	bump := "auto"
 
-	if bumpStr := actions.GetInput("bump"); bumpStr != "" {
+	if bumpStr := input("bump"); bumpStr != "" {
 		if !stringInSlice(bumpStr, validBumpStrategies) {
 			return Params{}, fmt.Errorf("invalid bump value: %s", bumpStr)
 		}
@@ -74,19 +179,50 @@ func LoadParams() (Params, error) {
 		bump = bumpStr
 	}
 
+	bumpSource := "branch"
+
+	if bumpSourceStr := input("bump_source"); bumpSourceStr != "" {
+		if !stringInSlice(bumpSourceStr, validBumpSources) {
+			return Params{}, fmt.Errorf("invalid bump source value: %s", bumpSourceStr)
+		}
+
+		bumpSource = bumpSourceStr
+	}
+
+	var initialDevelopment bool
+
+	if initialDevelopmentStr := input("initial_development"); initialDevelopmentStr != "" {
+		parsed, err := strconv.ParseBool(initialDevelopmentStr)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid initial_development argument: %s", initialDevelopmentStr)
+		}
+
+		initialDevelopment = parsed
+	}
+
 	branchingModel := "git-flow"
 
-	if branchingModelStr := actions.GetInput("branching_model"); branchingModelStr != "" {
-		if !stringInSlice(branchingModelStr, validBranchingModels) {
+	if branchingModelStr := input("branching_model"); branchingModelStr != "" {
+		if !stringInSlice(branchingModelStr, strategy.Names()) {
 			return Params{}, fmt.Errorf("invalid branching model value: %s", branchingModelStr)
 		}
 
 		branchingModel = branchingModelStr
 	}
 
+	mergeFormat := "auto"
+
+	if mergeFormatStr := input("merge_message_format"); mergeFormatStr != "" {
+		if !stringInSlice(mergeFormatStr, git.MergeFormats()) {
+			return Params{}, fmt.Errorf("invalid merge message format value: %s", mergeFormatStr)
+		}
+
+		mergeFormat = mergeFormatStr
+	}
+
 	var patchPattern = branchBugfixPrefixRegex
 
-	if patchPatternStr := actions.GetInput("patch_regex"); patchPatternStr != "" {
+	if patchPatternStr := input("patch_regex"); patchPatternStr != "" {
 		compiled, err := regex.Compile(patchPatternStr)
 		if err != nil {
 			return Params{}, fmt.Errorf("invalid patch pattern value: %s", patchPatternStr)
@@ -97,7 +233,7 @@ func LoadParams() (Params, error) {
 
 	var minorPattern = branchFeaturePrefixRegex
 
-	if minorPatternStr := actions.GetInput("minor_regex"); minorPatternStr != "" {
+	if minorPatternStr := input("minor_regex"); minorPatternStr != "" {
 		compiled, err := regex.Compile(minorPatternStr)
 		if err != nil {
 			return Params{}, fmt.Errorf("invalid minor pattern value: %s", minorPatternStr)
@@ -108,7 +244,7 @@ func LoadParams() (Params, error) {
 
 	var majorPattern = branchMajorPrefixRegex
 
-	if majorPatternStr := actions.GetInput("major_regex"); majorPatternStr != "" {
+	if majorPatternStr := input("major_regex"); majorPatternStr != "" {
 		compiled, err := regex.Compile(majorPatternStr)
 		if err != nil {
 			return Params{}, fmt.Errorf("invalid major pattern value: %s", majorPatternStr)
@@ -119,7 +255,7 @@ func LoadParams() (Params, error) {
 
 	var buildPattern = branchBuildPatternRegex
 
-	if buildPatternStr := actions.GetInput("build_regex"); buildPatternStr != "" {
+	if buildPatternStr := input("build_regex"); buildPatternStr != "" {
 		compiled, err := regex.Compile(buildPatternStr)
 		if err != nil {
 			return Params{}, fmt.Errorf("invalid build pattern value: %s", buildPatternStr)
@@ -131,7 +267,7 @@ func LoadParams() (Params, error) {
 
 	var hotfixPattern = branchHotfixPatternRegex
 
-	if hotfixPatternStr := actions.GetInput("hotfix_regex"); hotfixPatternStr != "" {
+	if hotfixPatternStr := input("hotfix_regex"); hotfixPatternStr != "" {
 		compiled, err := regex.Compile(hotfixPatternStr)
 		if err != nil {
 			return Params{}, fmt.Errorf("invalid hotfix pattern value: %s", hotfixPatternStr)
@@ -142,7 +278,7 @@ func LoadParams() (Params, error) {
 
 	var excludePattern regex.Regex
 
-	if excludePatternStr := actions.GetInput("exclude_regex"); excludePatternStr != "" {
+	if excludePatternStr := input("exclude_regex"); excludePatternStr != "" {
 		compiled, err := regex.Compile(excludePatternStr)
 		if err != nil {
 			return Params{}, fmt.Errorf("invalid exclude pattern value: %s", excludePatternStr)
@@ -151,12 +287,247 @@ func LoadParams() (Params, error) {
 		excludePattern = compiled
 	}
 
-	includeTagPattern := actions.GetInput("include_tag_pattern")
-	excludeTagPattern := actions.GetInput("exclude_tag_pattern")
+	var maintenancePattern = branchMaintenanceRegex
+
+	if maintenancePatternStr := input("maintenance_regex"); maintenancePatternStr != "" {
+		compiled, err := regex.Compile(maintenancePatternStr)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid maintenance pattern value: %s", maintenancePatternStr)
+		}
+
+		maintenancePattern = compiled
+	}
+
+	var rules strategy.Rules
+
+	if rulesStr := input("rules"); rulesStr != "" {
+		parsed, err := parseRules(rulesStr)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid rules value: %s", err)
+		}
+
+		rules = parsed
+	}
+
+	var labels []string
+
+	if labelsStr := input("labels"); labelsStr != "" {
+		for _, label := range strings.Split(labelsStr, ",") {
+			if label = strings.TrimSpace(label); label != "" {
+				labels = append(labels, label)
+			}
+		}
+	} else if event != nil {
+		labels = event.Labels()
+	}
+
+	labelBumps := map[string]string{
+		"semver:major": "major",
+		"semver:minor": "minor",
+		"semver:patch": "patch",
+		"semver:skip":  "skip",
+	}
+
+	if labelBumpsStr := input("label_bumps"); labelBumpsStr != "" {
+		parsed, err := parseLabelBumps(labelBumpsStr)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid label bumps value: %s", err)
+		}
+
+		labelBumps = parsed
+	}
+
+	calVerFormat := "YYYY.MM.MICRO"
+
+	if calVerFormatStr := input("calver_format"); calVerFormatStr != "" {
+		if _, err := strategy.ParseCalVerFormat(calVerFormatStr); err != nil {
+			return Params{}, fmt.Errorf("invalid calver format value: %s", err)
+		}
+
+		calVerFormat = calVerFormatStr
+	}
+
+	var strategyOptions map[string]string
+
+	if strategyOptionsStr := input("strategy_options"); strategyOptionsStr != "" {
+		if err := json.Unmarshal([]byte(strategyOptionsStr), &strategyOptions); err != nil {
+			return Params{}, fmt.Errorf("invalid strategy options value: %s", err)
+		}
+	}
+
+	var prereleaseChannels []PrereleaseChannel
+
+	if prereleaseChannelsStr := input("prerelease_channels"); prereleaseChannelsStr != "" {
+		parsed, err := parsePrereleaseChannels(prereleaseChannelsStr)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid prerelease channels value: %s", err)
+		}
+
+		prereleaseChannels = parsed
+	}
+
+	includeTagPattern := input("include_tag_pattern")
+	excludeTagPattern := input("exclude_tag_pattern")
+
+	var components []Component
+
+	if componentsStr := input("components"); componentsStr != "" {
+		parsed, err := parseComponents(componentsStr)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid components value: %s", err)
+		}
+
+		components = parsed
+	}
+
+	var versionFiles []VersionFile
+
+	if versionFilesStr := input("version_files"); versionFilesStr != "" {
+		parsed, err := parseVersionFiles(versionFilesStr, components)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid version files value: %s", err)
+		}
+
+		versionFiles = parsed
+	}
+
+	var includePaths []glob.Glob
+
+	if includePathsStr := input("include_paths"); includePathsStr != "" {
+		parsed, err := parseGlobs(includePathsStr)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid include paths value: %s", err)
+		}
+
+		includePaths = parsed
+	}
+
+	var excludePaths []glob.Glob
+
+	if excludePathsStr := input("exclude_paths"); excludePathsStr != "" {
+		parsed, err := parseGlobs(excludePathsStr)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid exclude paths value: %s", err)
+		}
+
+		excludePaths = parsed
+	}
+
+	unmatchedPaths := "skip"
+
+	if unmatchedPathsStr := input("unmatched_paths"); unmatchedPathsStr != "" {
+		if !stringInSlice(unmatchedPathsStr, validUnmatchedPaths) {
+			return Params{}, fmt.Errorf("invalid unmatched paths value: %s", unmatchedPathsStr)
+		}
+
+		unmatchedPaths = unmatchedPathsStr
+	}
+
+	var dockerTags []dockertag.Kind
+
+	if dockerTagsStr := input("docker_tags"); dockerTagsStr != "" {
+		for _, kind := range strings.Split(dockerTagsStr, ",") {
+			kind := dockertag.Kind(strings.TrimSpace(kind))
+
+			if !kindInSlice(kind, dockertag.Kinds()) {
+				return Params{}, fmt.Errorf("invalid docker tags value: %s", kind)
+			}
+
+			dockerTags = append(dockerTags, kind)
+		}
+	}
+
+	dockerImage := input("docker_image")
+
+	if dockerImage != "" && len(dockerTags) == 0 {
+		return Params{}, fmt.Errorf("docker_image requires docker_tags to be set")
+	}
+
+	dockerTagsSeparator := "\n"
+
+	if dockerTagsSeparatorStr := input("docker_tags_separator"); dockerTagsSeparatorStr != "" {
+		separator, ok := validDockerTagSeparators[dockerTagsSeparatorStr]
+		if !ok {
+			return Params{}, fmt.Errorf("invalid docker tags separator value: %s", dockerTagsSeparatorStr)
+		}
+
+		dockerTagsSeparator = separator
+	}
+
+	var createTag bool
+
+	if createTagStr := input("create_tag"); createTagStr != "" {
+		parsed, err := strconv.ParseBool(createTagStr)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid create_tag argument: %s", createTagStr)
+		}
+
+		createTag = parsed
+	}
+
+	var pushTag bool
+
+	if pushTagStr := input("push_tag"); pushTagStr != "" {
+		parsed, err := strconv.ParseBool(pushTagStr)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid push_tag argument: %s", pushTagStr)
+		}
+
+		pushTag = parsed
+	}
+
+	if pushTag && !createTag {
+		return Params{}, fmt.Errorf("push_tag requires create_tag to be enabled")
+	}
+
+	var tagMessage *template.Template
+
+	if tagMessageStr := input("tag_message"); tagMessageStr != "" {
+		parsed, err := parseTagMessage(tagMessageStr)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid tag message template: %s", err)
+		}
+
+		tagMessage = parsed
+	}
+
+	remote := "origin"
+
+	if remoteStr := input("remote"); remoteStr != "" {
+		remote = remoteStr
+	}
+
+	var generateChangelog bool
+
+	if changelogStr := input("changelog"); changelogStr != "" {
+		parsed, err := strconv.ParseBool(changelogStr)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid changelog argument: %s", changelogStr)
+		}
+
+		generateChangelog = parsed
+	}
+
+	changelogFile := input("changelog_file")
+
+	if changelogFile != "" && !generateChangelog {
+		return Params{}, fmt.Errorf("changelog_file requires changelog to be enabled")
+	}
+
+	var explain bool
+
+	if explainStr := input("explain"); explainStr != "" {
+		parsed, err := strconv.ParseBool(explainStr)
+		if err != nil {
+			return Params{}, fmt.Errorf("invalid explain argument: %s", explainStr)
+		}
+
+		explain = parsed
+	}
 
 	var debug bool
 
-	if debugStr := actions.GetInput("debug"); debugStr != "" {
+	if debugStr := input("debug"); debugStr != "" {
 		parsed, err := strconv.ParseBool(debugStr)
 		if err != nil {
 			return Params{}, fmt.Errorf("invalid debug argument: %s", debugStr)
@@ -167,13 +538,13 @@ func LoadParams() (Params, error) {
 
 	prefix := "v"
 
-	if prefixStr := actions.GetInput("prefix"); prefixStr != "" {
+	if prefixStr := input("prefix"); prefixStr != "" {
 		prefix = prefixStr
 	}
 
 	var baseVersion *semver.Version
 
-	if baseVersionStr := actions.GetInput("base_version"); baseVersionStr != "" {
+	if baseVersionStr := input("base_version"); baseVersionStr != "" {
 		prefixRe := regexp.MustCompile(fmt.Sprintf("^%s", prefix))
 		baseVersionStr = prefixRe.ReplaceAllLiteralString(baseVersionStr, "")
 
@@ -185,46 +556,137 @@ func LoadParams() (Params, error) {
 		baseVersion = &parsed
 	}
 
+	goModule := "off"
+
+	if goModuleStr := input("go_module"); goModuleStr != "" {
+		if !stringInSlice(goModuleStr, validGoModuleModes) {
+			return Params{}, fmt.Errorf("invalid go module value: %s", goModuleStr)
+		}
+
+		goModule = goModuleStr
+	}
+
+	apiDiff := "off"
+
+	if apiDiffStr := input("api_diff"); apiDiffStr != "" {
+		if !stringInSlice(apiDiffStr, validAPIDiffModes) {
+			return Params{}, fmt.Errorf("invalid api diff value: %s", apiDiffStr)
+		}
+
+		apiDiff = apiDiffStr
+	}
+
+	var goModuleDir string
+
+	if goModuleDirStr := input("go_module_dir"); goModuleDirStr != "" {
+		goModuleDir = path.Clean(strings.TrimSuffix(goModuleDirStr, "/"))
+
+		if path.IsAbs(goModuleDir) || goModuleDir == ".." || strings.HasPrefix(goModuleDir, "../") {
+			return Params{}, fmt.Errorf("invalid go module dir value: %s", goModuleDirStr)
+		}
+
+		// nested module tags are prefixed by its dir, e.g. sub/dir/v1.2.3
+		if goModuleDir != "." {
+			prefix = goModuleDir + "/" + prefix
+
+			if includeTagPattern == "" {
+				includeTagPattern = prefix + "[0-9]*"
+			}
+		}
+	}
+
 	mainBranchName := "master"
 
-	if mainBranchNameStr := actions.GetInput("main_branch_name"); mainBranchNameStr != "" {
+	if mainBranchNameStr := input("main_branch_name"); mainBranchNameStr != "" {
 		mainBranchName = mainBranchNameStr
 	}
 
 	developBranchName := "develop"
 
-	if developBranchNameStr := actions.GetInput("develop_branch_name"); developBranchNameStr != "" {
+	if developBranchNameStr := input("develop_branch_name"); developBranchNameStr != "" {
 		developBranchName = developBranchNameStr
 	}
 
 	prereleaseID := "pre"
 
-	if prereleaseIDStr := actions.GetInput("prerelease_id"); prereleaseIDStr != "" {
+	if prereleaseIDStr := input("prerelease_id"); prereleaseIDStr != "" {
 		prereleaseID = prereleaseIDStr
 	}
 
 	return Params{
-		CommitSha:         commitSha,
-		RepoDir:           repoDir,
-		Bump:              bump,
-		BranchingModel:    branchingModel,
-		BaseVersion:       baseVersion,
-		Prefix:            prefix,
-		PrereleaseID:      prereleaseID,
-		MainBranchName:    mainBranchName,
-		DevelopBranchName: developBranchName,
-		PatchPattern:      patchPattern,
-		MinorPattern:      minorPattern,
-		MajorPattern:      majorPattern,
-		BuildPattern:      buildPattern,
-		HotfixPattern:     hotfixPattern,
-		ExcludePattern:    excludePattern,
-		IncludeTagPattern: includeTagPattern,
-		ExcludeTagPattern: excludeTagPattern,
-		Debug:             debug,
+		CommitSha:           commitSha,
+		RepoDir:             repoDir,
+		ConfigFile:          configFile,
+		Bump:                bump,
+		BumpSource:          bumpSource,
+		InitialDevelopment:  initialDevelopment,
+		BranchingModel:      branchingModel,
+		MergeFormat:         mergeFormat,
+		BaseVersion:         baseVersion,
+		Prefix:              prefix,
+		PrereleaseID:        prereleaseID,
+		PrereleaseChannels:  prereleaseChannels,
+		MainBranchName:      mainBranchName,
+		DevelopBranchName:   developBranchName,
+		PatchPattern:        patchPattern,
+		MinorPattern:        minorPattern,
+		MajorPattern:        majorPattern,
+		BuildPattern:        buildPattern,
+		HotfixPattern:       hotfixPattern,
+		ExcludePattern:      excludePattern,
+		MaintenancePattern:  maintenancePattern,
+		Rules:               rules,
+		Labels:              labels,
+		LabelBumps:          labelBumps,
+		CalVerFormat:        calVerFormat,
+		StrategyOptions:     strategyOptions,
+		IncludeTagPattern:   includeTagPattern,
+		ExcludeTagPattern:   excludeTagPattern,
+		Components:          components,
+		VersionFiles:        versionFiles,
+		GoModule:            goModule,
+		GoModuleDir:         goModuleDir,
+		APIDiff:             apiDiff,
+		IncludePaths:        includePaths,
+		ExcludePaths:        excludePaths,
+		UnmatchedPaths:      unmatchedPaths,
+		DockerTags:          dockerTags,
+		DockerImage:         dockerImage,
+		DockerTagsSeparator: dockerTagsSeparator,
+		CreateTag:           createTag,
+		PushTag:             pushTag,
+		TagMessage:          tagMessage,
+		Remote:              remote,
+		Changelog:           generateChangelog,
+		ChangelogFile:       changelogFile,
+		Explain:             explain,
+		Event:               event,
+		Ref:                 inputOrEnv(input, "ref", "GITHUB_REF"),
+		HeadRef:             inputOrEnv(input, "head_ref", "GITHUB_HEAD_REF"),
+		BaseRef:             inputOrEnv(input, "base_ref", "GITHUB_BASE_REF"),
+		Debug:               debug,
 	}, nil
 }
 
+// inputOrEnv returns the input value or the environment variable if not set.
+func inputOrEnv(input func(name string) string, name, env string) string {
+	if value := input(name); value != "" {
+		return value
+	}
+
+	return os.Getenv(env)
+}
+
+func kindInSlice(a dockertag.Kind, list []dockertag.Kind) bool {
+	for _, b := range list {
+		if b == a {
+			return true
+		}
+	}
+
+	return false
+}
+
 func stringInSlice(a string, list []string) bool {
 	for _, b := range list {
 		if b == a {
@@ -246,17 +708,57 @@ func (p Params) String() string {
 		excludePattern = p.ExcludePattern.String()
 	}
 
+	channels := make([]string, len(p.PrereleaseChannels))
+	for i, channel := range p.PrereleaseChannels {
+		channels[i] = channel.Branch.String() + " -> " + channel.PrereleaseID
+	}
+
+	ruleStrings := make([]string, len(p.Rules))
+	for i, rule := range p.Rules {
+		ruleStrings[i] = ruleString(rule)
+	}
+
+	componentNames := make([]string, len(p.Components))
+	for i, component := range p.Components {
+		componentNames[i] = component.Name
+	}
+
+	versionFilePaths := make([]string, len(p.VersionFiles))
+	for i, file := range p.VersionFiles {
+		versionFilePaths[i] = file.Path
+	}
+
+	var tagMessage string
+	if p.TagMessage != nil {
+		tagMessage = p.TagMessage.Root.String()
+	}
+
+	var eventName string
+	if p.Event != nil {
+		eventName = p.Event.Name
+	}
+
 	return fmt.Sprintf(
-		"commit sha: %q, bump: %q, base version: %q, prefix: %q,"+
-			" prerelease id: %q, main branch name: %q, develop branch name: %q,"+
+		"commit sha: %q, bump: %q, bump source: %q, initial development: %t, merge message format: %q,"+
+			" base version: %q, prefix: %q,"+
+			" prerelease id: %q, prerelease channels: %q, main branch name: %q, develop branch name: %q,"+
 			" patch pattern: %q, minor pattern: %q, major pattern: %q, build pattern: %q,"+
-			" hotfix pattern %q, exclude pattern: %q, include tag pattern: %q,"+
-			" exclude tag pattern: %q, repo dir: %q, debug: %t",
+			" hotfix pattern %q, exclude pattern: %q, maintenance pattern: %q, rules: %q, labels: %q, label bumps: %q, calver format: %q, strategy options: %q,"+
+			" include tag pattern: %q,"+
+			" exclude tag pattern: %q, components: %q, version files: %q, go module: %q, go module dir: %q, api diff: %q,"+
+			" include paths: %q, exclude paths: %q, unmatched paths: %q,"+
+			" docker tags: %q, docker image: %q, docker tags separator: %q, create tag: %t, push tag: %t,"+
+			" tag message: %q, remote: %q, changelog: %t, changelog file: %q, explain: %t, event name: %q, ref: %q, head ref: %q, base ref: %q,"+
+			" repo dir: %q, config file: %q, debug: %t",
 		p.CommitSha,
 		p.Bump,
+		p.BumpSource,
+		p.InitialDevelopment,
+		p.MergeFormat,
 		baseVersion,
 		p.Prefix,
 		p.PrereleaseID,
+		channels,
 		p.MainBranchName,
 		p.DevelopBranchName,
 		p.PatchPattern.String(),
@@ -265,9 +767,38 @@ func (p Params) String() string {
 		p.BuildPattern.String(),
 		p.HotfixPattern.String(),
 		excludePattern,
+		p.MaintenancePattern.String(),
+		ruleStrings,
+		p.Labels,
+		p.LabelBumps,
+		p.CalVerFormat,
+		p.StrategyOptions,
 		p.IncludeTagPattern,
 		p.ExcludeTagPattern,
+		componentNames,
+		versionFilePaths,
+		p.GoModule,
+		p.GoModuleDir,
+		p.APIDiff,
+		globsString(p.IncludePaths),
+		globsString(p.ExcludePaths),
+		p.UnmatchedPaths,
+		p.DockerTags,
+		p.DockerImage,
+		p.DockerTagsSeparator,
+		p.CreateTag,
+		p.PushTag,
+		tagMessage,
+		p.Remote,
+		p.Changelog,
+		p.ChangelogFile,
+		p.Explain,
+		eventName,
+		p.Ref,
+		p.HeadRef,
+		p.BaseRef,
 		p.RepoDir,
+		p.ConfigFile,
 		p.Debug,
 	)
 }
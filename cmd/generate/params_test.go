@@ -2,15 +2,25 @@ package generate_test
 
 import (
 	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/blang/semver/v4"
 	"github.com/gandarez/semver-action/cmd/generate"
+	"github.com/gandarez/semver-action/internal/dockertag"
+	"github.com/gandarez/semver-action/internal/glob"
+	"github.com/gandarez/semver-action/internal/versionfile"
+	"github.com/gandarez/semver-action/pkg/actions"
+	"github.com/gandarez/semver-action/pkg/strategy"
 
 	"github.com/alecthomas/assert"
 	"github.com/stretchr/testify/require"
 )
 
+// nolint: gochecknoglobals
+var registerOnce sync.Once
+
 func TestLoadParams_Prefix(t *testing.T) {
 	require.NoError(t, os.Setenv("INPUT_PREFIX", "ver"))
 	defer func() { require.NoError(t, os.Unsetenv("INPUT_PREFIX")) }()
@@ -45,6 +55,52 @@ func TestLoadParams_PrereleaseID_Default(t *testing.T) {
 	assert.Equal(t, "pre", params.PrereleaseID)
 }
 
+func TestLoadParams_PrereleaseChannels(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_PRERELEASE_CHANNELS", `[
+		{"branch": "^develop$", "prerelease_id": "alpha"},
+		{"branch": "^release/.+", "prerelease_id": "beta"}
+	]`))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_PRERELEASE_CHANNELS")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	require.Len(t, params.PrereleaseChannels, 2)
+	assert.Equal(t, "^develop$", params.PrereleaseChannels[0].Branch.String())
+	assert.Equal(t, "alpha", params.PrereleaseChannels[0].PrereleaseID)
+	assert.Equal(t, "^release/.+", params.PrereleaseChannels[1].Branch.String())
+	assert.Equal(t, "beta", params.PrereleaseChannels[1].PrereleaseID)
+}
+
+func TestLoadParams_PrereleaseChannels_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Empty(t, params.PrereleaseChannels)
+}
+
+func TestLoadParams_PrereleaseChannels_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"invalid json":          `{`,
+		"no branch":             `[{"prerelease_id": "beta"}]`,
+		"invalid branch":        `[{"branch": "[", "prerelease_id": "beta"}]`,
+		"no prerelease id":      `[{"branch": "^release/.+"}]`,
+		"invalid prerelease id": `[{"branch": "^release/.+", "prerelease_id": "be.ta"}]`,
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, os.Setenv("INPUT_PRERELEASE_CHANNELS", value))
+			defer func() { require.NoError(t, os.Unsetenv("INPUT_PRERELEASE_CHANNELS")) }()
+
+			_, err := generate.LoadParams()
+			require.Error(t, err)
+
+			assert.Contains(t, err.Error(), "invalid prerelease channels value")
+		})
+	}
+}
+
 func TestLoadParams_MainBranchName(t *testing.T) {
 	require.NoError(t, os.Setenv("INPUT_MAIN_BRANCH_NAME", "main"))
 	defer func() { require.NoError(t, os.Unsetenv("INPUT_MAIN_BRANCH_NAME")) }()
@@ -229,6 +285,360 @@ func TestLoadParams_ExcludePattern_Default(t *testing.T) {
 	assert.Nil(t, params.ExcludePattern)
 }
 
+func TestLoadParams_MaintenancePattern(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_MAINTENANCE_REGEX", `^maint/(\d+)$`))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_MAINTENANCE_REGEX")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, `^maint/(\d+)$`, params.MaintenancePattern.String())
+}
+
+func TestLoadParams_MaintenancePattern_Invalid(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_MAINTENANCE_REGEX", "["))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_MAINTENANCE_REGEX")) }()
+
+	_, err := generate.LoadParams()
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "invalid maintenance pattern value: [")
+}
+
+func TestLoadParams_MaintenancePattern_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, `(?i)^release/(\d+)\.(\d+|x)$`, params.MaintenancePattern.String())
+}
+
+func TestLoadParams_CalVerFormat(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_CALVER_FORMAT", "YY.0W.N"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_CALVER_FORMAT")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, "YY.0W.N", params.CalVerFormat)
+}
+
+func TestLoadParams_CalVerFormat_Invalid(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_CALVER_FORMAT", "YYYY.MM"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_CALVER_FORMAT")) }()
+
+	_, err := generate.LoadParams()
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "invalid calver format value")
+}
+
+func TestLoadParams_CalVerFormat_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, "YYYY.MM.MICRO", params.CalVerFormat)
+}
+
+func TestLoadParams_StrategyOptions(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_STRATEGY_OPTIONS", `{"release_branch": "ship", "freeze": "true"}`))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_STRATEGY_OPTIONS")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"release_branch": "ship", "freeze": "true"}, params.StrategyOptions)
+}
+
+func TestLoadParams_StrategyOptions_Invalid(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_STRATEGY_OPTIONS", `{"freeze": true}`))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_STRATEGY_OPTIONS")) }()
+
+	_, err := generate.LoadParams()
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "invalid strategy options value")
+}
+
+func TestLoadParams_Rules(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_RULES", `[
+		{"source": "^chore/.+", "dest": "^main$", "method": "none"},
+		{"source": "^deps/.+", "dest": "^develop$", "method": "build", "version": "build"},
+		{"source": "^perf/.+", "method": "patch"}
+	]`))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_RULES")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	require.Len(t, params.Rules, 3)
+
+	assert.Equal(t, "^chore/.+", params.Rules[0].Source.String())
+	assert.Equal(t, "^main$", params.Rules[0].Dest.String())
+	assert.Empty(t, params.Rules[0].Method)
+	assert.Equal(t, "build", params.Rules[1].Method)
+	assert.Equal(t, "build", params.Rules[1].Version)
+	assert.Nil(t, params.Rules[2].Dest)
+	assert.Equal(t, "patch", params.Rules[2].Method)
+}
+
+func TestLoadParams_Rules_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"invalid json":          `{`,
+		"invalid source":        `[{"source": "[", "method": "patch"}]`,
+		"invalid dest":          `[{"dest": "[", "method": "patch"}]`,
+		"no method":             `[{"source": "^perf/.+"}]`,
+		"invalid method":        `[{"source": "^perf/.+", "method": "micro"}]`,
+		"invalid version":       `[{"source": "^perf/.+", "method": "build", "version": "final"}]`,
+		"version without build": `[{"source": "^perf/.+", "method": "patch", "version": "patch"}]`,
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, os.Setenv("INPUT_RULES", value))
+			defer func() { require.NoError(t, os.Unsetenv("INPUT_RULES")) }()
+
+			_, err := generate.LoadParams()
+			require.Error(t, err)
+
+			assert.Contains(t, err.Error(), "invalid rules value")
+		})
+	}
+}
+
+func TestLoadParams_Labels(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_LABELS", "semver:minor, docs"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_LABELS")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"semver:minor", "docs"}, params.Labels)
+}
+
+func TestLoadParams_Labels_Event(t *testing.T) {
+	fp := writeEventFile(t, `{"pull_request": {"number": 12, "labels": [{"name": "semver:major"}]}}`)
+
+	require.NoError(t, os.Setenv("GITHUB_EVENT_NAME", "pull_request"))
+	require.NoError(t, os.Setenv("GITHUB_EVENT_PATH", fp))
+
+	defer func() {
+		require.NoError(t, os.Unsetenv("GITHUB_EVENT_NAME"))
+		require.NoError(t, os.Unsetenv("GITHUB_EVENT_PATH"))
+	}()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"semver:major"}, params.Labels)
+}
+
+func TestLoadParams_LabelBumps(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_LABEL_BUMPS", `{"breaking": "major", "no-release": "skip"}`))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_LABEL_BUMPS")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{"breaking": "major", "no-release": "skip"}, params.LabelBumps)
+}
+
+func TestLoadParams_LabelBumps_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		"semver:major": "major",
+		"semver:minor": "minor",
+		"semver:patch": "patch",
+		"semver:skip":  "skip",
+	}, params.LabelBumps)
+}
+
+func TestLoadParams_LabelBumps_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"invalid json": `{`,
+		"invalid bump": `{"breaking": "final"}`,
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, os.Setenv("INPUT_LABEL_BUMPS", value))
+			defer func() { require.NoError(t, os.Unsetenv("INPUT_LABEL_BUMPS")) }()
+
+			_, err := generate.LoadParams()
+			require.Error(t, err)
+
+			assert.Contains(t, err.Error(), "invalid label bumps value")
+		})
+	}
+}
+
+func TestLoadParams_APIDiff(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_API_DIFF", "bump"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_API_DIFF")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, "bump", params.APIDiff)
+}
+
+func TestLoadParams_APIDiff_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, "off", params.APIDiff)
+}
+
+func TestLoadParams_APIDiff_Invalid(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_API_DIFF", "strict"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_API_DIFF")) }()
+
+	_, err := generate.LoadParams()
+	require.Error(t, err)
+
+	assert.EqualError(t, err, "invalid api diff value: strict")
+}
+
+func TestLoadParams_Paths(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_INCLUDE_PATHS", "cmd/, pkg/**/*.go,"))
+	require.NoError(t, os.Setenv("INPUT_EXCLUDE_PATHS", "**/*_test.go"))
+	require.NoError(t, os.Setenv("INPUT_UNMATCHED_PATHS", "build"))
+
+	defer func() {
+		require.NoError(t, os.Unsetenv("INPUT_INCLUDE_PATHS"))
+		require.NoError(t, os.Unsetenv("INPUT_EXCLUDE_PATHS"))
+		require.NoError(t, os.Unsetenv("INPUT_UNMATCHED_PATHS"))
+	}()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, []glob.Glob{glob.MustCompile("cmd/"), glob.MustCompile("pkg/**/*.go")}, params.IncludePaths)
+	assert.Equal(t, []glob.Glob{glob.MustCompile("**/*_test.go")}, params.ExcludePaths)
+	assert.Equal(t, "build", params.UnmatchedPaths)
+}
+
+func TestLoadParams_Paths_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Empty(t, params.IncludePaths)
+	assert.Empty(t, params.ExcludePaths)
+	assert.Equal(t, "skip", params.UnmatchedPaths)
+}
+
+func TestLoadParams_Paths_Invalid(t *testing.T) {
+	tests := map[string]struct {
+		Input       string
+		Value       string
+		ExpectedErr string
+	}{
+		"include paths": {
+			Input:       "INPUT_INCLUDE_PATHS",
+			Value:       "docs/[a",
+			ExpectedErr: `invalid include paths value: invalid glob pattern "docs/[a": missing ]`,
+		},
+		"exclude paths": {
+			Input:       "INPUT_EXCLUDE_PATHS",
+			Value:       "*.md, [",
+			ExpectedErr: `invalid exclude paths value: invalid glob pattern "[": missing ]`,
+		},
+		"unmatched paths": {
+			Input:       "INPUT_UNMATCHED_PATHS",
+			Value:       "patch",
+			ExpectedErr: "invalid unmatched paths value: patch",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, os.Setenv(test.Input, test.Value))
+			defer func() { require.NoError(t, os.Unsetenv(test.Input)) }()
+
+			_, err := generate.LoadParams()
+			require.Error(t, err)
+
+			assert.EqualError(t, err, test.ExpectedErr)
+		})
+	}
+}
+
+func TestLoadParams_GoModule(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_GO_MODULE", "warn"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_GO_MODULE")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, "warn", params.GoModule)
+}
+
+func TestLoadParams_GoModule_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, "off", params.GoModule)
+	assert.Empty(t, params.GoModuleDir)
+}
+
+func TestLoadParams_GoModule_Invalid(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_GO_MODULE", "fail"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_GO_MODULE")) }()
+
+	_, err := generate.LoadParams()
+	require.Error(t, err)
+
+	assert.EqualError(t, err, "invalid go module value: fail")
+}
+
+func TestLoadParams_GoModuleDir(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_GO_MODULE_DIR", "sub/dir/"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_GO_MODULE_DIR")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, "sub/dir", params.GoModuleDir)
+	assert.Equal(t, "sub/dir/v", params.Prefix)
+	assert.Equal(t, "sub/dir/v[0-9]*", params.IncludeTagPattern)
+}
+
+func TestLoadParams_GoModuleDir_IncludeTagPattern(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_GO_MODULE_DIR", "sub/dir"))
+	require.NoError(t, os.Setenv("INPUT_INCLUDE_TAG_PATTERN", "sub/dir/v2.*"))
+
+	defer func() {
+		require.NoError(t, os.Unsetenv("INPUT_GO_MODULE_DIR"))
+		require.NoError(t, os.Unsetenv("INPUT_INCLUDE_TAG_PATTERN"))
+	}()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, "sub/dir/v2.*", params.IncludeTagPattern)
+}
+
+func TestLoadParams_GoModuleDir_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"absolute":       "/sub/dir",
+		"outside":        "../dir",
+		"outside nested": "sub/../../dir",
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, os.Setenv("INPUT_GO_MODULE_DIR", value))
+			defer func() { require.NoError(t, os.Unsetenv("INPUT_GO_MODULE_DIR")) }()
+
+			_, err := generate.LoadParams()
+			require.Error(t, err)
+
+			assert.Contains(t, err.Error(), "invalid go module dir value")
+		})
+	}
+}
+
 func TestLoadParams_IncludeTagPattern(t *testing.T) {
 	require.NoError(t, os.Setenv("INPUT_INCLUDE_TAG_PATTERN", "v[0-9]*"))
 	defer func() { require.NoError(t, os.Unsetenv("INPUT_INCLUDE_TAG_PATTERN")) }()
@@ -263,6 +673,320 @@ func TestLoadParams_ExcludeTagPattern_Default(t *testing.T) {
 	assert.Empty(t, params.ExcludeTagPattern)
 }
 
+func TestLoadParams_Components(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_COMPONENTS", `[
+		{"name": "api", "paths": ["services/api/**", "libs/shared/**"], "prefix": "api-v"},
+		{"name": "web", "paths": ["services/web/**"]}
+	]`))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_COMPONENTS")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	require.Len(t, params.Components, 2)
+
+	assert.Equal(t, "api", params.Components[0].Name)
+	assert.Equal(t, "api-v", params.Components[0].Prefix)
+	require.Len(t, params.Components[0].Paths, 2)
+	assert.Equal(t, "services/api/**", params.Components[0].Paths[0].String())
+	assert.Equal(t, "libs/shared/**", params.Components[0].Paths[1].String())
+
+	assert.Equal(t, "web", params.Components[1].Name)
+	assert.Equal(t, "web/v", params.Components[1].Prefix)
+}
+
+func TestLoadParams_Components_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Empty(t, params.Components)
+}
+
+func TestLoadParams_Components_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"not json":        `api`,
+		"no name":         `[{"paths": ["api/**"]}]`,
+		"no paths":        `[{"name": "api"}]`,
+		"duplicated name": `[{"name": "api", "paths": ["api/**"]}, {"name": "api", "paths": ["web/**"]}]`,
+		"same prefix":     `[{"name": "api", "paths": ["api/**"], "prefix": "v"}, {"name": "web", "paths": ["web/**"], "prefix": "v"}]`,
+		"invalid glob":    `[{"name": "api", "paths": ["api/[a"]}]`,
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, os.Setenv("INPUT_COMPONENTS", value))
+			defer func() { require.NoError(t, os.Unsetenv("INPUT_COMPONENTS")) }()
+
+			_, err := generate.LoadParams()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadParams_VersionFiles(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_COMPONENTS", `[{"name": "api", "paths": ["api/**"]}]`))
+	require.NoError(t, os.Setenv("INPUT_VERSION_FILES", `[
+		{"path": "charts/app/Chart.yaml"},
+		{"path": "api/package.json", "component": "api"},
+		{"path": "version.go", "pattern": "Version = \"(.+)\""},
+		{"path": "VERSION.txt", "type": "plain"}
+	]`))
+
+	defer func() {
+		require.NoError(t, os.Unsetenv("INPUT_COMPONENTS"))
+		require.NoError(t, os.Unsetenv("INPUT_VERSION_FILES"))
+	}()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	require.Len(t, params.VersionFiles, 4)
+
+	assert.Equal(t, versionfile.TypeChart, params.VersionFiles[0].Type)
+	assert.Equal(t, versionfile.TypeJSON, params.VersionFiles[1].Type)
+	assert.Equal(t, "api", params.VersionFiles[1].Component)
+	assert.Equal(t, versionfile.TypeRegex, params.VersionFiles[2].Type)
+	assert.Equal(t, `Version = "(.+)"`, params.VersionFiles[2].Pattern.String())
+	assert.Equal(t, versionfile.TypePlain, params.VersionFiles[3].Type)
+}
+
+func TestLoadParams_VersionFiles_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Empty(t, params.VersionFiles)
+}
+
+func TestLoadParams_VersionFiles_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"not json":              `VERSION`,
+		"no path":               `[{"type": "plain"}]`,
+		"unknown type":          `[{"path": "version.go"}]`,
+		"invalid type":          `[{"path": "VERSION", "type": "ini"}]`,
+		"invalid pattern":       `[{"path": "version.go", "pattern": "("}]`,
+		"pattern without group": `[{"path": "version.go", "pattern": "Version"}]`,
+		"regex without pattern": `[{"path": "version.go", "type": "regex"}]`,
+		"unknown component":     `[{"path": "VERSION", "component": "api"}]`,
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, os.Setenv("INPUT_VERSION_FILES", value))
+			defer func() { require.NoError(t, os.Unsetenv("INPUT_VERSION_FILES")) }()
+
+			_, err := generate.LoadParams()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadParams_DockerTags(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_DOCKER_TAGS", "version, minor,major,latest,edge,sha"))
+	require.NoError(t, os.Setenv("INPUT_DOCKER_IMAGE", "ghcr.io/owner/app"))
+	require.NoError(t, os.Setenv("INPUT_DOCKER_TAGS_SEPARATOR", "comma"))
+
+	defer func() {
+		require.NoError(t, os.Unsetenv("INPUT_DOCKER_TAGS"))
+		require.NoError(t, os.Unsetenv("INPUT_DOCKER_IMAGE"))
+		require.NoError(t, os.Unsetenv("INPUT_DOCKER_TAGS_SEPARATOR"))
+	}()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, []dockertag.Kind{
+		dockertag.Version,
+		dockertag.Minor,
+		dockertag.Major,
+		dockertag.Latest,
+		dockertag.Edge,
+		dockertag.Sha,
+	}, params.DockerTags)
+	assert.Equal(t, "ghcr.io/owner/app", params.DockerImage)
+	assert.Equal(t, ",", params.DockerTagsSeparator)
+}
+
+func TestLoadParams_DockerTags_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Nil(t, params.DockerTags)
+	assert.Empty(t, params.DockerImage)
+	assert.Equal(t, "\n", params.DockerTagsSeparator)
+}
+
+func TestLoadParams_DockerTags_Invalid(t *testing.T) {
+	tests := map[string]struct {
+		Env      map[string]string
+		Expected string
+	}{
+		"unknown kind": {
+			Env:      map[string]string{"INPUT_DOCKER_TAGS": "version,stable"},
+			Expected: "invalid docker tags value: stable",
+		},
+		"image without tags": {
+			Env:      map[string]string{"INPUT_DOCKER_IMAGE": "ghcr.io/owner/app"},
+			Expected: "docker_image requires docker_tags to be set",
+		},
+		"unknown separator": {
+			Env:      map[string]string{"INPUT_DOCKER_TAGS": "version", "INPUT_DOCKER_TAGS_SEPARATOR": "space"},
+			Expected: "invalid docker tags separator value: space",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range test.Env {
+				require.NoError(t, os.Setenv(key, value))
+			}
+
+			defer func() {
+				for key := range test.Env {
+					require.NoError(t, os.Unsetenv(key))
+				}
+			}()
+
+			_, err := generate.LoadParams()
+			require.Error(t, err)
+
+			assert.Equal(t, test.Expected, err.Error())
+		})
+	}
+}
+
+func TestLoadParams_CreateTag(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_CREATE_TAG", "true"))
+	require.NoError(t, os.Setenv("INPUT_PUSH_TAG", "true"))
+	require.NoError(t, os.Setenv("INPUT_TAG_MESSAGE", "Release {{ .Tag }}"))
+	require.NoError(t, os.Setenv("INPUT_REMOTE", "upstream"))
+
+	defer func() {
+		require.NoError(t, os.Unsetenv("INPUT_CREATE_TAG"))
+		require.NoError(t, os.Unsetenv("INPUT_PUSH_TAG"))
+		require.NoError(t, os.Unsetenv("INPUT_TAG_MESSAGE"))
+		require.NoError(t, os.Unsetenv("INPUT_REMOTE"))
+	}()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.True(t, params.CreateTag)
+	assert.True(t, params.PushTag)
+	assert.NotNil(t, params.TagMessage)
+	assert.Equal(t, "upstream", params.Remote)
+}
+
+func TestLoadParams_CreateTag_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.False(t, params.CreateTag)
+	assert.False(t, params.PushTag)
+	assert.Nil(t, params.TagMessage)
+	assert.Equal(t, "origin", params.Remote)
+}
+
+func TestLoadParams_CreateTag_Invalid(t *testing.T) {
+	tests := map[string]map[string]string{
+		"create tag":              {"INPUT_CREATE_TAG": "invalid"},
+		"push tag":                {"INPUT_CREATE_TAG": "true", "INPUT_PUSH_TAG": "invalid"},
+		"push tag without create": {"INPUT_PUSH_TAG": "true"},
+		"tag message":             {"INPUT_CREATE_TAG": "true", "INPUT_TAG_MESSAGE": "Release {{ .Tag"},
+	}
+
+	for name, envs := range tests {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range envs {
+				require.NoError(t, os.Setenv(key, value))
+			}
+
+			defer func() {
+				for key := range envs {
+					require.NoError(t, os.Unsetenv(key))
+				}
+			}()
+
+			_, err := generate.LoadParams()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadParams_Changelog(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_CHANGELOG", "true"))
+	require.NoError(t, os.Setenv("INPUT_CHANGELOG_FILE", "CHANGELOG.md"))
+
+	defer func() {
+		require.NoError(t, os.Unsetenv("INPUT_CHANGELOG"))
+		require.NoError(t, os.Unsetenv("INPUT_CHANGELOG_FILE"))
+	}()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.True(t, params.Changelog)
+	assert.Equal(t, "CHANGELOG.md", params.ChangelogFile)
+}
+
+func TestLoadParams_Changelog_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.False(t, params.Changelog)
+	assert.Empty(t, params.ChangelogFile)
+}
+
+func TestLoadParams_Changelog_Invalid(t *testing.T) {
+	tests := map[string]map[string]string{
+		"changelog":                     {"INPUT_CHANGELOG": "invalid"},
+		"changelog file without enable": {"INPUT_CHANGELOG_FILE": "CHANGELOG.md"},
+	}
+
+	for name, envs := range tests {
+		t.Run(name, func(t *testing.T) {
+			for key, value := range envs {
+				require.NoError(t, os.Setenv(key, value))
+			}
+
+			defer func() {
+				for key := range envs {
+					require.NoError(t, os.Unsetenv(key))
+				}
+			}()
+
+			_, err := generate.LoadParams()
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadParams_Explain(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_EXPLAIN", "true"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_EXPLAIN")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.True(t, params.Explain)
+}
+
+func TestLoadParams_Explain_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.False(t, params.Explain)
+}
+
+func TestLoadParams_Explain_Invalid(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_EXPLAIN", "invalid"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_EXPLAIN")) }()
+
+	_, err := generate.LoadParams()
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "invalid explain argument")
+}
+
 func TestLoadParams_CommitSha(t *testing.T) {
 	require.NoError(t, os.Setenv("GITHUB_SHA", "2f08f7b455ec64741d135216d19d7e0c4dd46458"))
 	defer func() { require.NoError(t, os.Unsetenv("GITHUB_SHA")) }()
@@ -281,6 +1005,92 @@ func TestLoadParams_CommitSha_Invalid(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestLoadParamsWith(t *testing.T) {
+	require.NoError(t, os.Setenv("GITHUB_SHA", "2f08f7b455ec64741d135216d19d7e0c4dd46458"))
+	defer func() { require.NoError(t, os.Unsetenv("GITHUB_SHA")) }()
+
+	inputs := map[string]string{
+		"commit_sha": "81918ffc",
+		"prefix":     "release-",
+		"head_ref":   "feature/some",
+	}
+
+	params, err := generate.LoadParamsWith(func(name string) string {
+		return inputs[name]
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "81918ffc", params.CommitSha)
+	assert.Equal(t, "release-", params.Prefix)
+	assert.Equal(t, "feature/some", params.HeadRef)
+}
+
+func TestLoadParams_Event(t *testing.T) {
+	require.NoError(t, os.Setenv("GITHUB_EVENT_NAME", "pull_request"))
+	require.NoError(t, os.Setenv("GITHUB_EVENT_PATH", writeEventFile(t, `{
+		"pull_request": {
+			"number": 12,
+			"merged": true,
+			"head": {"ref": "feature/some"},
+			"base": {"ref": "develop"}
+		}
+	}`)))
+	require.NoError(t, os.Setenv("GITHUB_HEAD_REF", "feature/some"))
+	require.NoError(t, os.Setenv("GITHUB_BASE_REF", "develop"))
+
+	defer func() {
+		require.NoError(t, os.Unsetenv("GITHUB_EVENT_NAME"))
+		require.NoError(t, os.Unsetenv("GITHUB_EVENT_PATH"))
+		require.NoError(t, os.Unsetenv("GITHUB_HEAD_REF"))
+		require.NoError(t, os.Unsetenv("GITHUB_BASE_REF"))
+	}()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, &actions.Event{
+		Name: "pull_request",
+		PullRequest: &actions.PullRequest{
+			Number: 12,
+			Merged: true,
+			Head:   actions.Branch{Ref: "feature/some"},
+			Base:   actions.Branch{Ref: "develop"},
+		},
+	}, params.Event)
+	assert.Equal(t, "feature/some", params.HeadRef)
+	assert.Equal(t, "develop", params.BaseRef)
+}
+
+func TestLoadParams_Ref(t *testing.T) {
+	require.NoError(t, os.Setenv("GITHUB_REF", "refs/pull/12/merge"))
+	defer func() { require.NoError(t, os.Unsetenv("GITHUB_REF")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, "refs/pull/12/merge", params.Ref)
+}
+
+func TestLoadParams_Event_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Nil(t, params.Event)
+}
+
+func TestLoadParams_Event_Invalid(t *testing.T) {
+	require.NoError(t, os.Setenv("GITHUB_EVENT_NAME", "push"))
+	require.NoError(t, os.Setenv("GITHUB_EVENT_PATH", writeEventFile(t, `{`)))
+
+	defer func() {
+		require.NoError(t, os.Unsetenv("GITHUB_EVENT_NAME"))
+		require.NoError(t, os.Unsetenv("GITHUB_EVENT_PATH"))
+	}()
+
+	_, err := generate.LoadParams()
+	require.Error(t, err)
+}
+
 func TestLoadParams_RepoDir(t *testing.T) {
 	require.NoError(t, os.Setenv("INPUT_REPO_DIR", "/var/tmp/project"))
 	defer func() { require.NoError(t, os.Unsetenv("INPUT_REPO_DIR")) }()
@@ -300,10 +1110,11 @@ func TestLoadParams_RepoDir_Default(t *testing.T) {
 
 func TestLoadParams_Bump(t *testing.T) {
 	tests := map[string]string{
-		"auto":  "auto",
-		"major": "major",
-		"minor": "minor",
-		"patch": "patch",
+		"auto":     "auto",
+		"major":    "major",
+		"minor":    "minor",
+		"patch":    "patch",
+		"graduate": "graduate",
 	}
 
 	for name, value := range tests {
@@ -327,10 +1138,111 @@ func TestLoadParams_Bump_Invalid(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestLoadParams_InitialDevelopment(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_INITIAL_DEVELOPMENT", "true"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_INITIAL_DEVELOPMENT")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.True(t, params.InitialDevelopment)
+}
+
+func TestLoadParams_InitialDevelopment_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.False(t, params.InitialDevelopment)
+}
+
+func TestLoadParams_InitialDevelopment_Invalid(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_INITIAL_DEVELOPMENT", "invalid"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_INITIAL_DEVELOPMENT")) }()
+
+	_, err := generate.LoadParams()
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "invalid initial_development argument")
+}
+
+func TestLoadParams_BumpSource(t *testing.T) {
+	tests := map[string]string{
+		"branch":  "branch",
+		"commits": "commits",
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, os.Setenv("INPUT_BUMP_SOURCE", value))
+			defer func() { require.NoError(t, os.Unsetenv("INPUT_BUMP_SOURCE")) }()
+
+			params, err := generate.LoadParams()
+			require.NoError(t, err)
+
+			assert.Equal(t, value, params.BumpSource)
+		})
+	}
+}
+
+func TestLoadParams_BumpSource_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, "branch", params.BumpSource)
+}
+
+func TestLoadParams_BumpSource_Invalid(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_BUMP_SOURCE", "invalid"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_BUMP_SOURCE")) }()
+
+	_, err := generate.LoadParams()
+	require.Error(t, err)
+}
+
+func TestLoadParams_MergeFormat(t *testing.T) {
+	tests := map[string]string{
+		"auto":      "auto",
+		"github":    "github",
+		"gitlab":    "gitlab",
+		"bitbucket": "bitbucket",
+		"azure":     "azure",
+		"git":       "git",
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, os.Setenv("INPUT_MERGE_MESSAGE_FORMAT", value))
+			defer func() { require.NoError(t, os.Unsetenv("INPUT_MERGE_MESSAGE_FORMAT")) }()
+
+			params, err := generate.LoadParams()
+			require.NoError(t, err)
+
+			assert.Equal(t, value, params.MergeFormat)
+		})
+	}
+}
+
+func TestLoadParams_MergeFormat_Default(t *testing.T) {
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, "auto", params.MergeFormat)
+}
+
+func TestLoadParams_MergeFormat_Invalid(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_MERGE_MESSAGE_FORMAT", "invalid"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_MERGE_MESSAGE_FORMAT")) }()
+
+	_, err := generate.LoadParams()
+	require.Error(t, err)
+}
+
 func TestLoadParams_BranchingModel(t *testing.T) {
 	tests := map[string]string{
 		"git flow":    "git-flow",
 		"trunk based": "trunk-based",
+		"github flow": "github-flow",
+		"calver":      "calver",
 	}
 
 	for name, value := range tests {
@@ -354,6 +1266,20 @@ func TestLoadParams_BranchingModel_Invalid(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestLoadParams_BranchingModel_Registered(t *testing.T) {
+	registerOnce.Do(func() {
+		strategy.Register("params-test", strategy.NewTrunkBased)
+	})
+
+	require.NoError(t, os.Setenv("INPUT_BRANCHING_MODEL", "params-test"))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_BRANCHING_MODEL")) }()
+
+	params, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	assert.Equal(t, "params-test", params.BranchingModel)
+}
+
 func TestLoadParams_BaseVersion(t *testing.T) {
 	require.NoError(t, os.Setenv("INPUT_BASE_VERSION", "1.2.3"))
 	defer func() { require.NoError(t, os.Unsetenv("INPUT_BASE_VERSION")) }()
@@ -394,6 +1320,9 @@ func TestLoadParams_Debug_Invalid(t *testing.T) {
 
 func TestLoadParams_String(t *testing.T) {
 	require.NoError(t, os.Setenv("INPUT_BUMP", "auto"))
+	require.NoError(t, os.Setenv("INPUT_BUMP_SOURCE", "commits"))
+	require.NoError(t, os.Setenv("INPUT_INITIAL_DEVELOPMENT", "true"))
+	require.NoError(t, os.Setenv("INPUT_MERGE_MESSAGE_FORMAT", "gitlab"))
 	require.NoError(t, os.Setenv("INPUT_BASE_VERSION", "1.2.3"))
 	require.NoError(t, os.Setenv("INPUT_PREFIX", "r"))
 	require.NoError(t, os.Setenv("INPUT_PRERELEASE_ID", "alpha"))
@@ -407,12 +1336,43 @@ func TestLoadParams_String(t *testing.T) {
 	require.NoError(t, os.Setenv("INPUT_BUILD_REGEX", "^build/.+"))
 	require.NoError(t, os.Setenv("INPUT_HOTFIX_REGEX", "^hotfix/.+"))
 	require.NoError(t, os.Setenv("INPUT_EXCLUDE_REGEX", "^ignore/.+"))
+	require.NoError(t, os.Setenv("INPUT_MAINTENANCE_REGEX", `^maint/(\d+)$`))
+	require.NoError(t, os.Setenv("INPUT_RULES", `[{"source": "^chore/.+", "method": "none"}, {"dest": "^dev$", "method": "build", "version": "build"}]`))
+	require.NoError(t, os.Setenv("INPUT_LABELS", "semver:minor"))
+	require.NoError(t, os.Setenv("INPUT_LABEL_BUMPS", `{"semver:minor": "minor"}`))
+	require.NoError(t, os.Setenv("INPUT_GO_MODULE", "error"))
+	require.NoError(t, os.Setenv("INPUT_API_DIFF", "verify"))
+	require.NoError(t, os.Setenv("INPUT_INCLUDE_PATHS", "cmd/, pkg/**/*.go"))
+	require.NoError(t, os.Setenv("INPUT_EXCLUDE_PATHS", "**/*_test.go"))
+	require.NoError(t, os.Setenv("INPUT_UNMATCHED_PATHS", "build"))
+	require.NoError(t, os.Setenv("INPUT_STRATEGY_OPTIONS", `{"release_branch": "ship"}`))
+	require.NoError(t, os.Setenv("INPUT_CALVER_FORMAT", "YY.0W.N"))
+	require.NoError(t, os.Setenv("INPUT_PRERELEASE_CHANNELS", `[{"branch": "^release/.+", "prerelease_id": "beta"}]`))
 	require.NoError(t, os.Setenv("INPUT_INCLUDE_TAG_PATTERN", "v[0-9]*"))
 	require.NoError(t, os.Setenv("INPUT_EXCLUDE_TAG_PATTERN", "v[0-9]*-pre*"))
+	require.NoError(t, os.Setenv("INPUT_COMPONENTS", `[{"name":"api","paths":["api/**"]},{"name":"web","paths":["web/**"]}]`))
+	require.NoError(t, os.Setenv("INPUT_VERSION_FILES", `[{"path":"VERSION"},{"path":"api/package.json","component":"api"}]`))
+	require.NoError(t, os.Setenv("INPUT_DOCKER_TAGS", "version, latest"))
+	require.NoError(t, os.Setenv("INPUT_DOCKER_IMAGE", "ghcr.io/owner/app"))
+	require.NoError(t, os.Setenv("INPUT_DOCKER_TAGS_SEPARATOR", "comma"))
+	require.NoError(t, os.Setenv("INPUT_CREATE_TAG", "true"))
+	require.NoError(t, os.Setenv("INPUT_PUSH_TAG", "true"))
+	require.NoError(t, os.Setenv("INPUT_TAG_MESSAGE", "Release {{ .Tag }}"))
+	require.NoError(t, os.Setenv("INPUT_REMOTE", "upstream"))
+	require.NoError(t, os.Setenv("INPUT_CHANGELOG", "true"))
+	require.NoError(t, os.Setenv("INPUT_CHANGELOG_FILE", "CHANGELOG.md"))
 	require.NoError(t, os.Setenv("INPUT_DEBUG", "true"))
+	require.NoError(t, os.Setenv("GITHUB_EVENT_NAME", "pull_request"))
+	require.NoError(t, os.Setenv("GITHUB_EVENT_PATH", writeEventFile(t, `{"pull_request": {"number": 12}}`)))
+	require.NoError(t, os.Setenv("GITHUB_REF", "refs/pull/12/merge"))
+	require.NoError(t, os.Setenv("GITHUB_HEAD_REF", "feature/some"))
+	require.NoError(t, os.Setenv("GITHUB_BASE_REF", "develop"))
 
 	defer func() {
 		require.NoError(t, os.Unsetenv("INPUT_BUMP"))
+		require.NoError(t, os.Unsetenv("INPUT_BUMP_SOURCE"))
+		require.NoError(t, os.Unsetenv("INPUT_INITIAL_DEVELOPMENT"))
+		require.NoError(t, os.Unsetenv("INPUT_MERGE_MESSAGE_FORMAT"))
 		require.NoError(t, os.Unsetenv("INPUT_BASE_VERSION"))
 		require.NoError(t, os.Unsetenv("INPUT_PREFIX"))
 		require.NoError(t, os.Unsetenv("INPUT_PRERELEASE_ID"))
@@ -426,9 +1386,37 @@ func TestLoadParams_String(t *testing.T) {
 		require.NoError(t, os.Unsetenv("INPUT_BUILD_REGEX"))
 		require.NoError(t, os.Unsetenv("INPUT_HOTFIX_REGEX"))
 		require.NoError(t, os.Unsetenv("INPUT_EXCLUDE_REGEX"))
+		require.NoError(t, os.Unsetenv("INPUT_MAINTENANCE_REGEX"))
+		require.NoError(t, os.Unsetenv("INPUT_CALVER_FORMAT"))
+		require.NoError(t, os.Unsetenv("INPUT_RULES"))
+		require.NoError(t, os.Unsetenv("INPUT_LABELS"))
+		require.NoError(t, os.Unsetenv("INPUT_LABEL_BUMPS"))
+		require.NoError(t, os.Unsetenv("INPUT_GO_MODULE"))
+		require.NoError(t, os.Unsetenv("INPUT_API_DIFF"))
+		require.NoError(t, os.Unsetenv("INPUT_INCLUDE_PATHS"))
+		require.NoError(t, os.Unsetenv("INPUT_EXCLUDE_PATHS"))
+		require.NoError(t, os.Unsetenv("INPUT_UNMATCHED_PATHS"))
+		require.NoError(t, os.Unsetenv("INPUT_STRATEGY_OPTIONS"))
+		require.NoError(t, os.Unsetenv("INPUT_PRERELEASE_CHANNELS"))
 		require.NoError(t, os.Unsetenv("INPUT_INCLUDE_TAG_PATTERN"))
 		require.NoError(t, os.Unsetenv("INPUT_EXCLUDE_TAG_PATTERN"))
+		require.NoError(t, os.Unsetenv("INPUT_COMPONENTS"))
+		require.NoError(t, os.Unsetenv("INPUT_VERSION_FILES"))
+		require.NoError(t, os.Unsetenv("INPUT_DOCKER_TAGS"))
+		require.NoError(t, os.Unsetenv("INPUT_DOCKER_IMAGE"))
+		require.NoError(t, os.Unsetenv("INPUT_DOCKER_TAGS_SEPARATOR"))
+		require.NoError(t, os.Unsetenv("INPUT_CREATE_TAG"))
+		require.NoError(t, os.Unsetenv("INPUT_PUSH_TAG"))
+		require.NoError(t, os.Unsetenv("INPUT_TAG_MESSAGE"))
+		require.NoError(t, os.Unsetenv("INPUT_REMOTE"))
+		require.NoError(t, os.Unsetenv("INPUT_CHANGELOG"))
+		require.NoError(t, os.Unsetenv("INPUT_CHANGELOG_FILE"))
 		require.NoError(t, os.Unsetenv("INPUT_DEBUG"))
+		require.NoError(t, os.Unsetenv("GITHUB_EVENT_NAME"))
+		require.NoError(t, os.Unsetenv("GITHUB_EVENT_PATH"))
+		require.NoError(t, os.Unsetenv("GITHUB_REF"))
+		require.NoError(t, os.Unsetenv("GITHUB_HEAD_REF"))
+		require.NoError(t, os.Unsetenv("GITHUB_BASE_REF"))
 	}()
 
 	params, err := generate.LoadParams()
@@ -436,9 +1424,13 @@ func TestLoadParams_String(t *testing.T) {
 
 	assert.Equal(t, `commit sha: "2f08f7b455ec64741d135216d19d7e0c4dd46458",`+
 		` bump: "auto",`+
+		` bump source: "commits",`+
+		` initial development: true,`+
+		` merge message format: "gitlab",`+
 		` base version: "1.2.3",`+
 		` prefix: "r",`+
 		` prerelease id: "alpha",`+
+		` prerelease channels: ["^release/.+ -> beta"],`+
 		` main branch name: "main",`+
 		` develop branch name: "dev",`+
 		` patch pattern: "^bugfix/.+",`+
@@ -447,9 +1439,46 @@ func TestLoadParams_String(t *testing.T) {
 		` build pattern: "^build/.+",`+
 		` hotfix pattern "^hotfix/.+",`+
 		` exclude pattern: "^ignore/.+",`+
+		` maintenance pattern: "^maint/(\\d+)$",`+
+		` rules: ["^chore/.+ -> *: none" "* -> ^dev$: build/build"],`+
+		` labels: ["semver:minor"],`+
+		` label bumps: map["semver:minor":"minor"],`+
+		` calver format: "YY.0W.N",`+
+		` strategy options: map["release_branch":"ship"],`+
 		` include tag pattern: "v[0-9]*",`+
 		` exclude tag pattern: "v[0-9]*-pre*",`+
+		` components: ["api" "web"],`+
+		` version files: ["VERSION" "api/package.json"],`+
+		` go module: "error",`+
+		` go module dir: "",`+
+		` api diff: "verify",`+
+		` include paths: "cmd/, pkg/**/*.go",`+
+		` exclude paths: "**/*_test.go",`+
+		` unmatched paths: "build",`+
+		` docker tags: ["version" "latest"],`+
+		` docker image: "ghcr.io/owner/app",`+
+		` docker tags separator: ",",`+
+		` create tag: true,`+
+		` push tag: true,`+
+		` tag message: "Release {{.Tag}}",`+
+		` remote: "upstream",`+
+		` changelog: true,`+
+		` changelog file: "CHANGELOG.md",`+
+		` explain: false,`+
+		` event name: "pull_request",`+
+		` ref: "refs/pull/12/merge",`+
+		` head ref: "feature/some",`+
+		` base ref: "develop",`+
 		` repo dir: "/var/tmp/project",`+
+		` config file: "",`+
 		` debug: true`,
 		params.String())
 }
+
+func writeEventFile(t *testing.T, payload string) string {
+	fp := filepath.Join(t.TempDir(), "event.json")
+
+	require.NoError(t, os.WriteFile(fp, []byte(payload), 0600))
+
+	return fp
+}
@@ -0,0 +1,108 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gandarez/semver-action/internal/glob"
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/strategy"
+	"github.com/gandarez/semver-action/pkg/trace"
+
+	"github.com/apex/log"
+)
+
+// nolint: gochecknoglobals
+var validUnmatchedPaths = []string{"skip", "build"}
+
+// parseGlobs parses the comma separated path globs.
+func parseGlobs(value string) ([]glob.Glob, error) {
+	var globs []glob.Glob
+
+	for _, pattern := range strings.Split(value, ",") {
+		pattern = strings.TrimSpace(pattern)
+		if pattern == "" {
+			continue
+		}
+
+		compiled, err := glob.Compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		globs = append(globs, compiled)
+	}
+
+	return globs, nil
+}
+
+// relevantFiles returns the files matching any include glob, or every file
+// without include globs, and matching no exclude glob.
+func relevantFiles(files []string, include, exclude []glob.Glob) []string {
+	var relevant []string
+
+	for _, file := range files {
+		if len(include) > 0 && !glob.MatchAny(include, []string{file}) {
+			continue
+		}
+
+		if glob.MatchAny(exclude, []string{file}) {
+			continue
+		}
+
+		relevant = append(relevant, file)
+	}
+
+	return relevant
+}
+
+// filterPaths checks the files changed between the latest tag and the commit
+// against the include and exclude paths. When none is relevant, the bump is
+// skipped or downgraded to build. Only auto major, minor and patch bumps are
+// filtered.
+func filterPaths(params Params, features strategy.Features, gc git.Git, dest, latestTag, bump, method, version string, tr *trace.Trace) (string, string, error) {
+	if len(params.IncludePaths) == 0 && len(params.ExcludePaths) == 0 {
+		return method, version, nil
+	}
+
+	if part := bumpedPartOf(features, method, version); bump != "auto" || partLevels[part] <= partLevels["build"] {
+		return method, version, nil
+	}
+
+	files, err := gc.ChangedFiles(latestTag, params.CommitSha)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to get changed files since latest tag: %s", err)
+	}
+
+	if relevant := relevantFiles(files, params.IncludePaths, params.ExcludePaths); len(relevant) > 0 {
+		log.Debugf("%d of %d changed files are relevant since %q\n", len(relevant), len(files), latestTag)
+
+		return method, version, nil
+	}
+
+	log.Infof("no relevant files changed since %q", latestTag)
+
+	tr.Notef("none of %d changed files is relevant since latest tag", len(files))
+
+	if params.UnmatchedPaths != "build" {
+		return "", "", nil
+	}
+
+	// not every model has a build bump, e.g. github-flow and calver
+	if !features.Supports("build") {
+		tr.Notef("%s model has no build bump, skipping", params.BranchingModel)
+
+		return "", "", nil
+	}
+
+	// build is not a release bump into every branch, e.g. main in git-flow
+	if !features.BuildsInto(dest) {
+		tr.Notef("%s model has no build bump into %s, skipping", params.BranchingModel, dest)
+
+		return "", "", nil
+	}
+
+	tr.Notef("bump downgraded from %s to build", bumpedPartOf(features, method, version))
+
+	return "build", "", nil
+}
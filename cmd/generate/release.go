@@ -0,0 +1,107 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+
+	"github.com/gandarez/semver-action/pkg/git"
+
+	"github.com/apex/log"
+)
+
+// tagMessageData contains the values available to the tag message template.
+type tagMessageData struct {
+	Tag          string
+	PreviousTag  string
+	AncestorTag  string
+	IsPrerelease bool
+	Component    string
+}
+
+// Release creates the calculated tags on the commit sha and pushes them to the
+// remote, when enabled and not in explain mode. It refuses to overwrite an
+// existing tag.
+func Release(params Params, gc git.Git, result Result) (Result, error) {
+	if !params.CreateTag {
+		return result, nil
+	}
+
+	if params.Explain {
+		result.Trace.Notef("dry run: tags not created")
+
+		return result, nil
+	}
+
+	if result.SemverTag != "" {
+		created, pushed, err := release(params, gc, tagMessageData{
+			Tag:          result.SemverTag,
+			PreviousTag:  result.PreviousTag,
+			AncestorTag:  result.AncestorTag,
+			IsPrerelease: result.IsPrerelease,
+		})
+		if err != nil {
+			return Result{}, err
+		}
+
+		result.TagCreated = created
+		result.TagPushed = pushed
+	}
+
+	for name, component := range result.Components {
+		created, pushed, err := release(params, gc, tagMessageData{
+			Tag:          component.SemverTag,
+			PreviousTag:  component.PreviousTag,
+			AncestorTag:  component.AncestorTag,
+			IsPrerelease: component.IsPrerelease,
+			Component:    name,
+		})
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to release component %q: %s", name, err)
+		}
+
+		component.TagCreated = created
+		component.TagPushed = pushed
+
+		result.Components[name] = component
+	}
+
+	return result, nil
+}
+
+func release(params Params, gc git.Git, data tagMessageData) (bool, bool, error) {
+	var message string
+
+	if params.TagMessage != nil {
+		var buf bytes.Buffer
+
+		if err := params.TagMessage.Execute(&buf, data); err != nil {
+			return false, false, fmt.Errorf("failed to render tag message: %s", err)
+		}
+
+		message = buf.String()
+	}
+
+	if err := gc.CreateTag(data.Tag, params.CommitSha, message); err != nil {
+		return false, false, fmt.Errorf("failed to create tag: %s", err)
+	}
+
+	log.Infof("created tag %s", data.Tag)
+
+	if !params.PushTag {
+		return true, false, nil
+	}
+
+	if err := gc.PushTag(params.Remote, data.Tag); err != nil {
+		return true, false, fmt.Errorf("failed to push tag: %s", err)
+	}
+
+	log.Infof("pushed tag %s to %s", data.Tag, params.Remote)
+
+	return true, true, nil
+}
+
+// parseTagMessage parses the tag message template.
+func parseTagMessage(text string) (*template.Template, error) {
+	return template.New("tag_message").Option("missingkey=error").Parse(text)
+}
@@ -0,0 +1,169 @@
+package generate_test
+
+import (
+	"errors"
+	"testing"
+	"text/template"
+
+	"github.com/gandarez/semver-action/cmd/generate"
+	"github.com/gandarez/semver-action/pkg/trace"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelease(t *testing.T) {
+	gc := &gitClientMock{
+		CreateTagFn: func(name, commitHash, message string) error {
+			assert.Equal(t, "v1.3.0", name)
+			assert.Equal(t, "81918ffc", commitHash)
+			assert.Equal(t, "Release v1.3.0 (previous v1.2.3)", message)
+
+			return nil
+		},
+		PushTagFn: func(remote, name string) error {
+			assert.Equal(t, "upstream", remote)
+			assert.Equal(t, "v1.3.0", name)
+
+			return nil
+		},
+	}
+
+	result, err := generate.Release(generate.Params{
+		CommitSha:  "81918ffc",
+		CreateTag:  true,
+		PushTag:    true,
+		TagMessage: template.Must(template.New("").Parse("Release {{ .Tag }} (previous {{ .PreviousTag }})")),
+		Remote:     "upstream",
+	}, gc, generate.Result{
+		PreviousTag: "v1.2.3",
+		SemverTag:   "v1.3.0",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, generate.Result{
+		PreviousTag: "v1.2.3",
+		SemverTag:   "v1.3.0",
+		TagCreated:  true,
+		TagPushed:   true,
+	}, result)
+	assert.Equal(t, 1, gc.CreateTagFnInvoked)
+	assert.Equal(t, 1, gc.PushTagFnInvoked)
+}
+
+func TestRelease_Lightweight(t *testing.T) {
+	gc := &gitClientMock{
+		CreateTagFn: func(name, commitHash, message string) error {
+			assert.Equal(t, "v1.3.0", name)
+			assert.Empty(t, message)
+
+			return nil
+		},
+	}
+
+	result, err := generate.Release(generate.Params{
+		CreateTag: true,
+	}, gc, generate.Result{
+		SemverTag: "v1.3.0",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, result.TagCreated)
+	assert.False(t, result.TagPushed)
+	assert.Zero(t, gc.PushTagFnInvoked)
+}
+
+func TestRelease_Components(t *testing.T) {
+	var created []string
+
+	gc := &gitClientMock{
+		CreateTagFn: func(name, commitHash, message string) error {
+			created = append(created, name)
+			assert.Equal(t, "Release api", message)
+
+			return nil
+		},
+	}
+
+	result, err := generate.Release(generate.Params{
+		CreateTag:  true,
+		TagMessage: template.Must(template.New("").Parse("Release {{ .Component }}")),
+	}, gc, generate.Result{
+		Components: map[string]generate.ComponentResult{
+			"api": {SemverTag: "api/v1.3.0"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"api/v1.3.0"}, created)
+	assert.True(t, result.Components["api"].TagCreated)
+	assert.False(t, result.TagCreated)
+}
+
+func TestRelease_Disabled(t *testing.T) {
+	gc := &gitClientMock{}
+
+	result, err := generate.Release(generate.Params{}, gc, generate.Result{SemverTag: "v1.3.0"})
+	require.NoError(t, err)
+
+	assert.False(t, result.TagCreated)
+	assert.Zero(t, gc.CreateTagFnInvoked)
+}
+
+func TestRelease_Explain(t *testing.T) {
+	gc := &gitClientMock{}
+
+	result, err := generate.Release(generate.Params{
+		CreateTag: true,
+		PushTag:   true,
+		Explain:   true,
+	}, gc, generate.Result{
+		SemverTag: "v1.3.0",
+		Trace:     &trace.Trace{},
+	})
+	require.NoError(t, err)
+
+	assert.False(t, result.TagCreated)
+	assert.False(t, result.TagPushed)
+	assert.Equal(t, []string{"dry run: tags not created"}, result.Trace.Notes)
+	assert.Zero(t, gc.CreateTagFnInvoked)
+	assert.Zero(t, gc.PushTagFnInvoked)
+}
+
+func TestRelease_NoVersionBump(t *testing.T) {
+	gc := &gitClientMock{}
+
+	result, err := generate.Release(generate.Params{CreateTag: true}, gc, generate.Result{})
+	require.NoError(t, err)
+
+	assert.False(t, result.TagCreated)
+	assert.Zero(t, gc.CreateTagFnInvoked)
+}
+
+func TestRelease_TagExists(t *testing.T) {
+	gc := &gitClientMock{
+		CreateTagFn: func(name, commitHash, message string) error {
+			return errors.New("tag v1.3.0 already exists")
+		},
+	}
+
+	_, err := generate.Release(generate.Params{CreateTag: true, PushTag: true}, gc, generate.Result{SemverTag: "v1.3.0"})
+
+	assert.EqualError(t, err, "failed to create tag: tag v1.3.0 already exists")
+	assert.Zero(t, gc.PushTagFnInvoked)
+}
+
+func TestRelease_PushErr(t *testing.T) {
+	gc := &gitClientMock{
+		CreateTagFn: func(name, commitHash, message string) error {
+			return nil
+		},
+		PushTagFn: func(remote, name string) error {
+			return errors.New("rejected")
+		},
+	}
+
+	_, err := generate.Release(generate.Params{CreateTag: true, PushTag: true, Remote: "origin"}, gc, generate.Result{SemverTag: "v1.3.0"})
+
+	assert.EqualError(t, err, "failed to push tag: rejected")
+}
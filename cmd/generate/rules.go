@@ -0,0 +1,94 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gandarez/semver-action/pkg/regex"
+	"github.com/gandarez/semver-action/pkg/strategy"
+)
+
+// nolint: gochecknoglobals
+var (
+	validRuleMethods  = []string{"none", "build", "major", "minor", "patch", "hotfix", "final", "graduate"}
+	validRuleVersions = []string{"major", "minor", "patch", "build"}
+)
+
+type ruleConfig struct {
+	Source  string `json:"source"`
+	Dest    string `json:"dest"`
+	Method  string `json:"method"`
+	Version string `json:"version"`
+}
+
+// parseRules parses the JSON list of bump rules. Empty source or dest patterns
+// match any branch, and method none means no bump.
+func parseRules(data string) (strategy.Rules, error) {
+	var configs []ruleConfig
+
+	if err := json.Unmarshal([]byte(data), &configs); err != nil {
+		return nil, err
+	}
+
+	rules := make(strategy.Rules, 0, len(configs))
+
+	for i, config := range configs {
+		var rule strategy.Rule
+
+		if config.Source != "" {
+			compiled, err := regex.Compile(config.Source)
+			if err != nil {
+				return nil, fmt.Errorf("rule at index %d has invalid source: %s", i, err)
+			}
+
+			rule.Source = compiled
+		}
+
+		if config.Dest != "" {
+			compiled, err := regex.Compile(config.Dest)
+			if err != nil {
+				return nil, fmt.Errorf("rule at index %d has invalid dest: %s", i, err)
+			}
+
+			rule.Dest = compiled
+		}
+
+		if !stringInSlice(config.Method, validRuleMethods) {
+			return nil, fmt.Errorf("rule at index %d has invalid method: %q", i, config.Method)
+		}
+
+		if config.Version != "" && (config.Method != "build" || !stringInSlice(config.Version, validRuleVersions)) {
+			return nil, fmt.Errorf("rule at index %d has invalid version: %q", i, config.Version)
+		}
+
+		if config.Method != "none" {
+			rule.Method = config.Method
+		}
+
+		rule.Version = config.Version
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// ruleString formats the rule as source -> dest: method/version.
+func ruleString(rule strategy.Rule) string {
+	source, dest, method := "*", "*", "none"
+
+	if rule.Source != nil {
+		source = rule.Source.String()
+	}
+
+	if rule.Dest != nil {
+		dest = rule.Dest.String()
+	}
+
+	if rule.Method != "" {
+		method = rule.Method
+	}
+
+	return strings.TrimSuffix(fmt.Sprintf("%s -> %s: %s/%s", source, dest, method, rule.Version), "/")
+}
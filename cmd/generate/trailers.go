@@ -0,0 +1,85 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gandarez/semver-action/pkg/git"
+
+	"github.com/blang/semver/v4"
+)
+
+// nolint: gochecknoglobals
+var validTrailerBumps = []string{"major", "minor", "patch", "skip"}
+
+// trailerOverrides contains the overrides read from the commit trailers.
+type trailerOverrides struct {
+	// Bump is major, minor, patch or skip. Empty means no override.
+	Bump string
+	// ReleaseAs is the version to release, if set.
+	ReleaseAs *semver.Version
+}
+
+// readTrailers reads the Release-As, Semver-Bump and Semver trailers of the
+// commit. Keys are case insensitive and the last occurrence wins.
+func readTrailers(gc git.Git, commitHash string) (trailerOverrides, error) {
+	trailers, err := gc.Trailers(commitHash)
+	if err != nil {
+		return trailerOverrides{}, fmt.Errorf("failed to read commit trailers: %s", err)
+	}
+
+	var overrides trailerOverrides
+
+	for _, trailer := range trailers {
+		switch strings.ToLower(trailer.Key) {
+		case "release-as":
+			parsed, err := semver.ParseTolerant(trailer.Value)
+			if err != nil {
+				return trailerOverrides{}, fmt.Errorf("invalid %s trailer: %s", trailer.Key, trailer.Value)
+			}
+
+			overrides.ReleaseAs = &parsed
+		case "semver-bump", "semver":
+			bump := strings.ToLower(trailer.Value)
+
+			if !stringInSlice(bump, validTrailerBumps) {
+				return trailerOverrides{}, fmt.Errorf("invalid %s trailer: %s", trailer.Key, trailer.Value)
+			}
+
+			overrides.Bump = bump
+		}
+	}
+
+	return overrides, nil
+}
+
+// releaseAs returns the result of the version forced by the Release-As trailer,
+// which must be greater than the latest tag and must not exist yet.
+func releaseAs(params Params, gc git.Git, latestTag string, version *semver.Version) (Result, error) {
+	latest := semver.MustParse(initialTag)
+
+	if latestTag != "" {
+		parsed, err := semver.ParseTolerant(strings.TrimPrefix(latestTag, params.Prefix))
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to parse tag %q or not valid semantic version: %s", latestTag, err)
+		}
+
+		latest = parsed
+	}
+
+	if !version.GT(latest) {
+		return Result{}, fmt.Errorf("release as %s must be greater than the latest tag %s", version, latest)
+	}
+
+	semverTag := params.Prefix + version.String()
+
+	if gc.TagExists(semverTag) {
+		return Result{}, fmt.Errorf("tag %s already exists", semverTag)
+	}
+
+	return Result{
+		PreviousTag:  params.Prefix + latest.String(),
+		SemverTag:    semverTag,
+		IsPrerelease: len(version.Pre) > 0,
+	}, nil
+}
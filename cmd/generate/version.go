@@ -0,0 +1,74 @@
+package generate
+
+import (
+	"strings"
+
+	"github.com/blang/semver/v4"
+)
+
+// SplitVersion sets the version without prefix, its major, minor and patch
+// numbers, prerelease and build metadata, and the part bumped since the
+// previous tag. Calendar versions not being valid semantic versions only get
+// the version without prefix.
+func SplitVersion(params Params, result Result) Result {
+	if result.SemverTag == "" {
+		return result
+	}
+
+	result.Version = strings.TrimPrefix(result.SemverTag, params.Prefix)
+
+	version, err := semver.ParseTolerant(result.Version)
+	if err != nil {
+		return result
+	}
+
+	result.Major = version.Major
+	result.Minor = version.Minor
+	result.Patch = version.Patch
+	result.Prerelease = strings.Join(prereleaseStrings(version.Pre), ".")
+	result.Build = strings.Join(version.Build, ".")
+
+	// calver may have no previous tag
+	previous, err := semver.ParseTolerant(strings.TrimPrefix(result.PreviousTag, params.Prefix))
+	if err != nil {
+		previous = semver.MustParse(initialTag)
+	}
+
+	result.Bump = bumpedPart(previous, version)
+	result.IsMajor = result.Bump == "major"
+	result.IsMinor = result.Bump == "minor"
+	result.IsPatch = result.Bump == "patch"
+
+	return result
+}
+
+// bumpedPart returns the highest part of version that differs from previous:
+// major, minor, patch, prerelease or build. A prerelease finalized into its
+// version is final, and the same version is none.
+func bumpedPart(previous, version semver.Version) string {
+	switch {
+	case version.Major != previous.Major:
+		return "major"
+	case version.Minor != previous.Minor:
+		return "minor"
+	case version.Patch != previous.Patch:
+		return "patch"
+	case len(version.Pre) > 0 && !version.Equals(previous):
+		return "prerelease"
+	case len(version.Pre) == 0 && len(previous.Pre) > 0:
+		return "final"
+	case strings.Join(version.Build, ".") != strings.Join(previous.Build, "."):
+		return "build"
+	default:
+		return "none"
+	}
+}
+
+func prereleaseStrings(pre []semver.PRVersion) []string {
+	identifiers := make([]string, len(pre))
+	for i, identifier := range pre {
+		identifiers[i] = identifier.String()
+	}
+
+	return identifiers
+}
@@ -0,0 +1,122 @@
+package generate_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/semver-action/cmd/generate"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitVersion(t *testing.T) {
+	tests := map[string]struct {
+		Result   generate.Result
+		Expected generate.Result
+	}{
+		"major": {
+			Result: generate.Result{PreviousTag: "v1.2.3", SemverTag: "v2.0.0"},
+			Expected: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v2.0.0",
+				Version:     "2.0.0",
+				Major:       2,
+				Bump:        "major",
+				IsMajor:     true,
+			},
+		},
+		"minor prerelease": {
+			Result: generate.Result{PreviousTag: "v1.2.3", SemverTag: "v1.3.0-pre.1", IsPrerelease: true},
+			Expected: generate.Result{
+				PreviousTag:  "v1.2.3",
+				SemverTag:    "v1.3.0-pre.1",
+				IsPrerelease: true,
+				Version:      "1.3.0-pre.1",
+				Major:        1,
+				Minor:        3,
+				Prerelease:   "pre.1",
+				Bump:         "minor",
+				IsMinor:      true,
+			},
+		},
+		"patch": {
+			Result: generate.Result{PreviousTag: "v1.2.3", SemverTag: "v1.2.4"},
+			Expected: generate.Result{
+				PreviousTag: "v1.2.3",
+				SemverTag:   "v1.2.4",
+				Version:     "1.2.4",
+				Major:       1,
+				Minor:       2,
+				Patch:       4,
+				Bump:        "patch",
+				IsPatch:     true,
+			},
+		},
+		"prerelease": {
+			Result: generate.Result{PreviousTag: "v1.3.0-pre.1", SemverTag: "v1.3.0-pre.2", IsPrerelease: true},
+			Expected: generate.Result{
+				PreviousTag:  "v1.3.0-pre.1",
+				SemverTag:    "v1.3.0-pre.2",
+				IsPrerelease: true,
+				Version:      "1.3.0-pre.2",
+				Major:        1,
+				Minor:        3,
+				Prerelease:   "pre.2",
+				Bump:         "prerelease",
+			},
+		},
+		"final": {
+			Result: generate.Result{PreviousTag: "v1.3.0-pre.2", SemverTag: "v1.3.0"},
+			Expected: generate.Result{
+				PreviousTag: "v1.3.0-pre.2",
+				SemverTag:   "v1.3.0",
+				Version:     "1.3.0",
+				Major:       1,
+				Minor:       3,
+				Bump:        "final",
+			},
+		},
+		"build": {
+			Result: generate.Result{PreviousTag: "v1.2.3+4", SemverTag: "v1.2.3+5"},
+			Expected: generate.Result{
+				PreviousTag: "v1.2.3+4",
+				SemverTag:   "v1.2.3+5",
+				Version:     "1.2.3+5",
+				Major:       1,
+				Minor:       2,
+				Patch:       3,
+				Build:       "5",
+				Bump:        "build",
+			},
+		},
+		"calver without previous tag": {
+			Result: generate.Result{SemverTag: "v24.06.0"},
+			Expected: generate.Result{
+				SemverTag: "v24.06.0",
+				Version:   "24.06.0",
+				Major:     24,
+				Minor:     6,
+				Bump:      "major",
+				IsMajor:   true,
+			},
+		},
+		"not a semantic version": {
+			Result: generate.Result{SemverTag: "v2024.06.18.1"},
+			Expected: generate.Result{
+				SemverTag: "v2024.06.18.1",
+				Version:   "2024.06.18.1",
+			},
+		},
+		"no version bump": {
+			Result:   generate.Result{PreviousTag: "v1.2.3"},
+			Expected: generate.Result{PreviousTag: "v1.2.3"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			result := generate.SplitVersion(generate.Params{Prefix: "v"}, test.Result)
+
+			assert.Equal(t, test.Expected, result)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/gandarez/semver-action/internal/versionfile"
+
+	"github.com/apex/log"
+)
+
+type (
+	// VersionFile contains a file to write the calculated version into.
+	VersionFile struct {
+		versionfile.Target
+		// Component writes the version of the monorepo component instead.
+		Component string
+	}
+
+	versionFileConfig struct {
+		Path      string `json:"path"`
+		Type      string `json:"type"`
+		Pattern   string `json:"pattern"`
+		Component string `json:"component"`
+	}
+)
+
+// parseVersionFiles parses the JSON list of version files. Type is detected by
+// the file name when not set, or is regex when a pattern is set.
+func parseVersionFiles(data string, components []Component) ([]VersionFile, error) {
+	var configs []versionFileConfig
+
+	if err := json.Unmarshal([]byte(data), &configs); err != nil {
+		return nil, err
+	}
+
+	files := make([]VersionFile, 0, len(configs))
+
+	for i, config := range configs {
+		if config.Path == "" {
+			return nil, fmt.Errorf("version file at index %d has no path", i)
+		}
+
+		typ := versionfile.Type(config.Type)
+
+		switch {
+		case typ == "" && config.Pattern != "":
+			typ = versionfile.TypeRegex
+		case typ == "":
+			detected, ok := versionfile.Detect(config.Path)
+			if !ok {
+				return nil, fmt.Errorf("version file %q has unknown type, set type or pattern", config.Path)
+			}
+
+			typ = detected
+		case !typeInSlice(typ, versionfile.Types()):
+			return nil, fmt.Errorf("version file %q has invalid type: %s", config.Path, typ)
+		}
+
+		var pattern *regexp.Regexp
+
+		if typ == versionfile.TypeRegex {
+			compiled, err := regexp.Compile(config.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("version file %q has invalid pattern: %s", config.Path, err)
+			}
+
+			if compiled.NumSubexp() < 1 {
+				return nil, fmt.Errorf("version file %q pattern must have one capture group", config.Path)
+			}
+
+			pattern = compiled
+		}
+
+		if config.Component != "" && !componentExists(config.Component, components) {
+			return nil, fmt.Errorf("version file %q has unknown component: %s", config.Path, config.Component)
+		}
+
+		files = append(files, VersionFile{
+			Target: versionfile.Target{
+				Path:    config.Path,
+				Type:    typ,
+				Pattern: pattern,
+			},
+			Component: config.Component,
+		})
+	}
+
+	return files, nil
+}
+
+// WriteVersionFiles writes the calculated version, without prefix, into the
+// version files. Files of components without a new version are skipped, as
+// are all files in explain mode. Nothing is written if any file fails.
+func WriteVersionFiles(params Params, result Result) (Result, error) {
+	if len(params.VersionFiles) == 0 {
+		return result, nil
+	}
+
+	var targets []versionfile.Target
+
+	for _, file := range params.VersionFiles {
+		version := versionFileVersion(params, result, file.Component)
+		if version == "" {
+			log.Debugf("no version to write into %s\n", file.Path)
+
+			continue
+		}
+
+		if params.Explain {
+			result.Trace.Notef("dry run: version %s not written to %s", version, file.Path)
+
+			continue
+		}
+
+		target := file.Target
+		target.Version = version
+
+		targets = append(targets, target)
+	}
+
+	changed, err := versionfile.Write(params.RepoDir, targets)
+	if err != nil {
+		return Result{}, err
+	}
+
+	versions := make(map[string]string, len(targets))
+	for _, target := range targets {
+		versions[target.Path] = target.Version
+	}
+
+	for _, path := range changed {
+		log.Infof("version %s written to %s", versions[path], path)
+	}
+
+	result.VersionFiles = changed
+
+	return result, nil
+}
+
+// versionFileVersion returns the calculated version without prefix of the
+// component, or of the repository when component is empty.
+func versionFileVersion(params Params, result Result, component string) string {
+	if component == "" {
+		if result.SemverTag == "" {
+			return ""
+		}
+
+		return strings.TrimPrefix(result.SemverTag, params.Prefix)
+	}
+
+	componentResult, ok := result.Components[component]
+	if !ok || componentResult.SemverTag == "" {
+		return ""
+	}
+
+	for _, c := range params.Components {
+		if c.Name == component {
+			return strings.TrimPrefix(componentResult.SemverTag, c.Prefix)
+		}
+	}
+
+	return ""
+}
+
+func componentExists(name string, components []Component) bool {
+	for _, component := range components {
+		if component.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+func typeInSlice(a versionfile.Type, list []versionfile.Type) bool {
+	for _, b := range list {
+		if b == a {
+			return true
+		}
+	}
+
+	return false
+}
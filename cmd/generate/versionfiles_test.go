@@ -0,0 +1,131 @@
+package generate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gandarez/semver-action/cmd/generate"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteVersionFiles(t *testing.T) {
+	repoDir := t.TempDir()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(repoDir, "api"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "VERSION"), []byte("1.2.3\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "api", "package.json"), []byte(`{"version": "0.4.0"}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "web.txt"), []byte("0.1.0"), 0600))
+
+	require.NoError(t, os.Setenv("INPUT_COMPONENTS", `[
+		{"name": "api", "paths": ["api/**"]},
+		{"name": "web", "paths": ["web/**"]}
+	]`))
+	require.NoError(t, os.Setenv("INPUT_VERSION_FILES", `[
+		{"path": "VERSION"},
+		{"path": "api/package.json", "component": "api"},
+		{"path": "web.txt", "type": "plain", "component": "web"}
+	]`))
+
+	defer func() {
+		require.NoError(t, os.Unsetenv("INPUT_COMPONENTS"))
+		require.NoError(t, os.Unsetenv("INPUT_VERSION_FILES"))
+	}()
+
+	p, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	p.RepoDir = repoDir
+
+	result, err := generate.WriteVersionFiles(p, generate.Result{
+		SemverTag: "v1.3.0",
+		Components: map[string]generate.ComponentResult{
+			"api": {SemverTag: "api/v0.5.0"},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"VERSION", "api/package.json"}, result.VersionFiles)
+
+	data, err := os.ReadFile(filepath.Join(repoDir, "VERSION"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.3.0\n", string(data))
+
+	data, err = os.ReadFile(filepath.Join(repoDir, "api", "package.json"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"version": "0.5.0"}`, string(data))
+
+	// web component has no new version
+	data, err = os.ReadFile(filepath.Join(repoDir, "web.txt"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "0.1.0", string(data))
+}
+
+func TestWriteVersionFiles_NoVersion(t *testing.T) {
+	repoDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "VERSION"), []byte("1.2.3\n"), 0600))
+
+	require.NoError(t, os.Setenv("INPUT_VERSION_FILES", `[{"path": "VERSION"}]`))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_VERSION_FILES")) }()
+
+	p, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	p.RepoDir = repoDir
+
+	result, err := generate.WriteVersionFiles(p, generate.Result{})
+	require.NoError(t, err)
+
+	assert.Empty(t, result.VersionFiles)
+
+	data, err := os.ReadFile(filepath.Join(repoDir, "VERSION"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.3\n", string(data))
+}
+
+func TestWriteVersionFiles_Err(t *testing.T) {
+	require.NoError(t, os.Setenv("INPUT_VERSION_FILES", `[{"path": "VERSION"}]`))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_VERSION_FILES")) }()
+
+	p, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	p.RepoDir = t.TempDir()
+
+	_, err = generate.WriteVersionFiles(p, generate.Result{SemverTag: "v1.3.0"})
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "failed to stat version file")
+}
+
+func TestWriteVersionFiles_NothingWrittenOnError(t *testing.T) {
+	repoDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "VERSION"), []byte("1.2.3\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "Chart.yaml"), []byte("name: app\n"), 0600))
+
+	require.NoError(t, os.Setenv("INPUT_VERSION_FILES", `[{"path": "VERSION"}, {"path": "Chart.yaml"}]`))
+	defer func() { require.NoError(t, os.Unsetenv("INPUT_VERSION_FILES")) }()
+
+	p, err := generate.LoadParams()
+	require.NoError(t, err)
+
+	p.RepoDir = repoDir
+
+	_, err = generate.WriteVersionFiles(p, generate.Result{SemverTag: "v1.3.0"})
+	require.Error(t, err)
+
+	assert.Equal(t, "failed to write version into Chart.yaml: version not found", err.Error())
+
+	data, err := os.ReadFile(filepath.Join(repoDir, "VERSION"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.3\n", string(data))
+}
@@ -0,0 +1,334 @@
+package apidiff
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// Level is the kind of change of the exported API.
+type Level int
+
+const (
+	// None means the exported API did not change.
+	None Level = iota
+	// Compatible means the exported API only got additions.
+	Compatible
+	// Incompatible means the exported API got removals or changes breaking
+	// its users.
+	Incompatible
+)
+
+// String returns the name of the level.
+func (l Level) String() string {
+	switch l {
+	case Compatible:
+		return "compatible"
+	case Incompatible:
+		return "incompatible"
+	default:
+		return "none"
+	}
+}
+
+// Bump returns the version part the level requires: major, minor or empty.
+func (l Level) Bump() string {
+	switch l {
+	case Compatible:
+		return "minor"
+	case Incompatible:
+		return "major"
+	default:
+		return ""
+	}
+}
+
+type (
+	// Change is a change of the exported API.
+	Change struct {
+		// Package is the package path relative to the module root, "." for the root.
+		Package string
+		// Message describes the change, e.g. "Client.Close: removed".
+		Message    string
+		Compatible bool
+	}
+
+	// Report contains the changes of the exported API, sorted by package.
+	Report struct {
+		Changes []Change
+	}
+)
+
+// String returns the change as "<package>: <message>".
+func (c Change) String() string {
+	return c.Package + ": " + c.Message
+}
+
+// Level returns the highest level of the changes.
+func (r Report) Level() Level {
+	level := None
+
+	for _, change := range r.Changes {
+		if !change.Compatible {
+			return Incompatible
+		}
+
+		level = Compatible
+	}
+
+	return level
+}
+
+// Compare type checks the Go module at the old and new dirs and returns the
+// changes of the exported API of its non-internal packages.
+func Compare(oldDir, newDir string) (Report, error) {
+	oldPkgs, err := Load(oldDir)
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to load %s: %s", oldDir, err)
+	}
+
+	newPkgs, err := Load(newDir)
+	if err != nil {
+		return Report{}, fmt.Errorf("failed to load %s: %s", newDir, err)
+	}
+
+	var r reporter
+
+	for _, rel := range sortedKeys(oldPkgs) {
+		newPkg, ok := newPkgs[rel]
+		if !ok {
+			r.incompatible(rel, "package removed")
+			continue
+		}
+
+		comparePackages(&r, rel, oldPkgs[rel], newPkg)
+	}
+
+	for _, rel := range sortedKeys(newPkgs) {
+		if _, ok := oldPkgs[rel]; !ok {
+			r.compatible(rel, "package added")
+		}
+	}
+
+	return Report{Changes: r.changes}, nil
+}
+
+type reporter struct {
+	changes []Change
+}
+
+func (r *reporter) compatible(pkg, format string, args ...interface{}) {
+	r.changes = append(r.changes, Change{Package: pkg, Message: fmt.Sprintf(format, args...), Compatible: true})
+}
+
+func (r *reporter) incompatible(pkg, format string, args ...interface{}) {
+	r.changes = append(r.changes, Change{Package: pkg, Message: fmt.Sprintf(format, args...)})
+}
+
+func comparePackages(r *reporter, rel string, oldPkg, newPkg *types.Package) {
+	oldScope, newScope := oldPkg.Scope(), newPkg.Scope()
+
+	for _, name := range oldScope.Names() {
+		oldObj := oldScope.Lookup(name)
+		if !oldObj.Exported() {
+			continue
+		}
+
+		newObj := newScope.Lookup(name)
+		if newObj == nil || !newObj.Exported() {
+			r.incompatible(rel, "%s: removed", name)
+			continue
+		}
+
+		compareObjects(r, rel, oldObj, newObj)
+	}
+
+	for _, name := range newScope.Names() {
+		if newScope.Lookup(name).Exported() && oldScope.Lookup(name) == nil {
+			r.compatible(rel, "%s: added", name)
+		}
+	}
+}
+
+func compareObjects(r *reporter, rel string, oldObj, newObj types.Object) {
+	name := oldObj.Name()
+
+	if kind(oldObj) != kind(newObj) {
+		r.incompatible(rel, "%s: changed from %s to %s", name, kind(oldObj), kind(newObj))
+		return
+	}
+
+	switch oldObj := oldObj.(type) {
+	case *types.Const:
+		newObj := newObj.(*types.Const)
+
+		if typeString(oldObj.Type()) != typeString(newObj.Type()) {
+			r.incompatible(rel, "%s: type changed from %s to %s", name, typeString(oldObj.Type()), typeString(newObj.Type()))
+		} else if oldObj.Val().ExactString() != newObj.Val().ExactString() {
+			r.incompatible(rel, "%s: value changed from %s to %s", name, oldObj.Val(), newObj.Val())
+		}
+	case *types.TypeName:
+		compareTypes(r, rel, name, oldObj.Type(), newObj.Type())
+	default:
+		if typeString(oldObj.Type()) != typeString(newObj.Type()) {
+			r.incompatible(rel, "%s: changed from %s to %s", name, typeString(oldObj.Type()), typeString(newObj.Type()))
+		}
+	}
+}
+
+func compareTypes(r *reporter, rel, name string, oldType, newType types.Type) {
+	oldNamed, oldOk := oldType.(*types.Named)
+	newNamed, newOk := newType.(*types.Named)
+
+	if oldOk != newOk {
+		r.incompatible(rel, "%s: changed from %s to %s", name, typeString(oldType.Underlying()), typeString(newType.Underlying()))
+		return
+	}
+
+	if oldOk && typeParamsString(oldNamed) != typeParamsString(newNamed) {
+		r.incompatible(rel, "%s: type parameters changed from [%s] to [%s]", name, typeParamsString(oldNamed), typeParamsString(newNamed))
+		return
+	}
+
+	oldUnderlying, newUnderlying := oldType.Underlying(), newType.Underlying()
+
+	switch oldUnderlying := oldUnderlying.(type) {
+	case *types.Struct:
+		if newUnderlying, ok := newUnderlying.(*types.Struct); ok {
+			compareMembers(r, rel, name, structFields(oldUnderlying), structFields(newUnderlying), true)
+			compareMethods(r, rel, name, oldType, newType)
+
+			return
+		}
+	case *types.Interface:
+		if newUnderlying, ok := newUnderlying.(*types.Interface); ok {
+			// implementations of the interface break when methods are added
+			compareMembers(r, rel, name, interfaceMethods(oldUnderlying), interfaceMethods(newUnderlying), false)
+
+			return
+		}
+	}
+
+	if typeString(oldUnderlying) != typeString(newUnderlying) {
+		r.incompatible(rel, "%s: changed from %s to %s", name, typeString(oldUnderlying), typeString(newUnderlying))
+		return
+	}
+
+	compareMethods(r, rel, name, oldType, newType)
+}
+
+// compareMethods compares the exported methods of the pointer method sets.
+func compareMethods(r *reporter, rel, name string, oldType, newType types.Type) {
+	if _, ok := oldType.(*types.Named); !ok {
+		return
+	}
+
+	compareMembers(r, rel, name, methodSet(oldType), methodSet(newType), true)
+}
+
+// compareMembers reports removed and changed members as incompatible, and added
+// ones as compatible when additions are allowed.
+func compareMembers(r *reporter, rel, name string, oldMembers, newMembers map[string]string, additionsAllowed bool) {
+	for _, member := range sortedKeys(oldMembers) {
+		newMember, ok := newMembers[member]
+
+		switch {
+		case !ok:
+			r.incompatible(rel, "%s.%s: removed", name, member)
+		case newMember != oldMembers[member]:
+			r.incompatible(rel, "%s.%s: changed from %s to %s", name, member, oldMembers[member], newMember)
+		}
+	}
+
+	for _, member := range sortedKeys(newMembers) {
+		if _, ok := oldMembers[member]; ok {
+			continue
+		}
+
+		if additionsAllowed {
+			r.compatible(rel, "%s.%s: added", name, member)
+		} else {
+			r.incompatible(rel, "%s.%s: added", name, member)
+		}
+	}
+}
+
+func structFields(s *types.Struct) map[string]string {
+	fields := make(map[string]string)
+
+	for i := 0; i < s.NumFields(); i++ {
+		if field := s.Field(i); field.Exported() {
+			fields[field.Name()] = typeString(field.Type())
+		}
+	}
+
+	return fields
+}
+
+func interfaceMethods(iface *types.Interface) map[string]string {
+	methods := make(map[string]string)
+
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		methods[method.Name()] = typeString(method.Type())
+	}
+
+	return methods
+}
+
+func methodSet(typ types.Type) map[string]string {
+	methods := make(map[string]string)
+
+	set := types.NewMethodSet(types.NewPointer(typ))
+
+	for i := 0; i < set.Len(); i++ {
+		if method := set.At(i).Obj(); method.Exported() {
+			methods[method.Name()] = typeString(method.Type())
+		}
+	}
+
+	return methods
+}
+
+func typeParamsString(named *types.Named) string {
+	params := named.TypeParams()
+
+	names := make([]string, params.Len())
+	for i := 0; i < params.Len(); i++ {
+		names[i] = typeString(params.At(i).Constraint())
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func kind(obj types.Object) string {
+	switch obj.(type) {
+	case *types.Const:
+		return "const"
+	case *types.Var:
+		return "var"
+	case *types.Func:
+		return "func"
+	case *types.TypeName:
+		return "type"
+	default:
+		return "object"
+	}
+}
+
+// typeString qualifies types by package path, which is the same at both dirs.
+func typeString(typ types.Type) string {
+	return types.TypeString(typ, nil)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
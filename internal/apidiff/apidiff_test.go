@@ -0,0 +1,170 @@
+package apidiff_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gandarez/semver-action/internal/apidiff"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompare(t *testing.T) {
+	tests := map[string]struct {
+		Old      map[string]string
+		New      map[string]string
+		Expected []apidiff.Change
+		Level    apidiff.Level
+	}{
+		"no change": {
+			Old:   map[string]string{"lib.go": "package lib\n\nfunc Do() error { return nil }\n"},
+			New:   map[string]string{"lib.go": "package lib\n\n// Do does.\nfunc Do() error { return nil }\n\nfunc do() {}\n"},
+			Level: apidiff.None,
+		},
+		"func added": {
+			Old: map[string]string{"lib.go": "package lib\n\nfunc Do() {}\n"},
+			New: map[string]string{"lib.go": "package lib\n\nfunc Do() {}\n\nfunc Undo() {}\n"},
+			Expected: []apidiff.Change{
+				{Package: ".", Message: "Undo: added", Compatible: true},
+			},
+			Level: apidiff.Compatible,
+		},
+		"func removed": {
+			Old: map[string]string{"lib.go": "package lib\n\nfunc Do() {}\n\nfunc Undo() {}\n"},
+			New: map[string]string{"lib.go": "package lib\n\nfunc Do() {}\n"},
+			Expected: []apidiff.Change{
+				{Package: ".", Message: "Undo: removed"},
+			},
+			Level: apidiff.Incompatible,
+		},
+		"signature changed": {
+			Old: map[string]string{"lib.go": "package lib\n\nimport \"context\"\n\nfunc Do(ctx context.Context) {}\n"},
+			New: map[string]string{"lib.go": "package lib\n\nimport \"context\"\n\nfunc Do(ctx context.Context, n int) {}\n"},
+			Expected: []apidiff.Change{
+				{Package: ".", Message: "Do: changed from func(ctx context.Context) to func(ctx context.Context, n int)"},
+			},
+			Level: apidiff.Incompatible,
+		},
+		"struct field and method added": {
+			Old: map[string]string{"lib.go": "package lib\n\ntype Client struct{ URL string }\n"},
+			New: map[string]string{"lib.go": "package lib\n\ntype Client struct {\n\tURL string\n\tTimeout int\n\tretries int\n}\n\nfunc (c *Client) Close() error { return nil }\n"},
+			Expected: []apidiff.Change{
+				{Package: ".", Message: "Client.Timeout: added", Compatible: true},
+				{Package: ".", Message: "Client.Close: added", Compatible: true},
+			},
+			Level: apidiff.Compatible,
+		},
+		"struct field type changed": {
+			Old: map[string]string{"lib.go": "package lib\n\ntype Client struct{ Timeout int }\n"},
+			New: map[string]string{"lib.go": "package lib\n\nimport \"time\"\n\ntype Client struct{ Timeout time.Duration }\n"},
+			Expected: []apidiff.Change{
+				{Package: ".", Message: "Client.Timeout: changed from int to time.Duration"},
+			},
+			Level: apidiff.Incompatible,
+		},
+		"interface method added": {
+			Old: map[string]string{"lib.go": "package lib\n\ntype Store interface{ Get(key string) string }\n"},
+			New: map[string]string{"lib.go": "package lib\n\ntype Store interface {\n\tGet(key string) string\n\tSet(key, value string)\n}\n"},
+			Expected: []apidiff.Change{
+				{Package: ".", Message: "Store.Set: added"},
+			},
+			Level: apidiff.Incompatible,
+		},
+		"const value changed": {
+			Old: map[string]string{"lib.go": "package lib\n\nconst Max = 10\n"},
+			New: map[string]string{"lib.go": "package lib\n\nconst Max = 20\n"},
+			Expected: []apidiff.Change{
+				{Package: ".", Message: "Max: value changed from 10 to 20"},
+			},
+			Level: apidiff.Incompatible,
+		},
+		"type of module package changed": {
+			Old: map[string]string{
+				"lib.go":        "package lib\n\nimport \"example.com/lib/model\"\n\nvar Default model.User\n",
+				"model/user.go": "package model\n\ntype User struct{ Name string }\n",
+			},
+			New: map[string]string{
+				"lib.go":        "package lib\n\nimport \"example.com/lib/model\"\n\nvar Default *model.User\n",
+				"model/user.go": "package model\n\ntype User struct{ Name string }\n",
+			},
+			Expected: []apidiff.Change{
+				{Package: ".", Message: "Default: changed from example.com/lib/model.User to *example.com/lib/model.User"},
+			},
+			Level: apidiff.Incompatible,
+		},
+		"packages added and removed": {
+			Old: map[string]string{
+				"lib.go":     "package lib\n",
+				"old/old.go": "package old\n\nfunc Do() {}\n",
+			},
+			New: map[string]string{
+				"lib.go":     "package lib\n",
+				"new/new.go": "package new\n\nfunc Do() {}\n",
+			},
+			Expected: []apidiff.Change{
+				{Package: "old", Message: "package removed"},
+				{Package: "new", Message: "package added", Compatible: true},
+			},
+			Level: apidiff.Incompatible,
+		},
+		"internal, main and test files are ignored": {
+			Old: map[string]string{
+				"lib.go":                "package lib\n",
+				"internal/util/util.go": "package util\n\nfunc Do() {}\n",
+				"cmd/app/main.go":       "package main\n\nfunc Do() {}\n\nfunc main() {}\n",
+				"lib_test.go":           "package lib\n\nfunc Helper() {}\n",
+			},
+			New: map[string]string{
+				"lib.go":                "package lib\n",
+				"internal/util/util.go": "package util\n",
+				"cmd/app/main.go":       "package main\n\nfunc main() {}\n",
+			},
+			Level: apidiff.None,
+		},
+		"external types are ignored": {
+			Old:   map[string]string{"lib.go": "package lib\n\nimport \"github.com/owner/dep\"\n\nfunc Do(c dep.Client) {}\n"},
+			New:   map[string]string{"lib.go": "package lib\n\nimport \"github.com/owner/dep\"\n\nfunc Do(c dep.Client) {}\n"},
+			Level: apidiff.None,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			report, err := apidiff.Compare(writeModule(t, test.Old), writeModule(t, test.New))
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, report.Changes)
+			assert.Equal(t, test.Level, report.Level())
+		})
+	}
+}
+
+func TestCompare_NoGoMod(t *testing.T) {
+	_, err := apidiff.Compare(t.TempDir(), writeModule(t, nil))
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "failed to read go.mod")
+}
+
+func TestLevel_Bump(t *testing.T) {
+	assert.Equal(t, "major", apidiff.Incompatible.Bump())
+	assert.Equal(t, "minor", apidiff.Compatible.Bump())
+	assert.Empty(t, apidiff.None.Bump())
+}
+
+func writeModule(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/lib\n\ngo 1.22\n"), 0600))
+
+	for name, content := range files {
+		fp := filepath.Join(dir, filepath.FromSlash(name))
+
+		require.NoError(t, os.MkdirAll(filepath.Dir(fp), 0755))
+		require.NoError(t, os.WriteFile(fp, []byte(content), 0600))
+	}
+
+	return dir
+}
@@ -0,0 +1,238 @@
+package apidiff
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gandarez/semver-action/internal/gomodule"
+)
+
+// loader type checks the packages of a module from source. Standard library
+// imports use the compiler export data, and imports of other modules resolve
+// to empty packages, so their types are invalid on both sides and never differ.
+type loader struct {
+	fset       *token.FileSet
+	root       string
+	modulePath string
+	std        types.Importer
+	packages   map[string]*types.Package
+	loading    map[string]bool
+}
+
+// Load type checks the non-internal library packages of the Go module at dir
+// and returns them by path relative to the module root. Type errors are
+// ignored, as an API is still comparable when dependencies are missing.
+func Load(dir string) (map[string]*types.Package, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod")) // nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.mod: %s", err)
+	}
+
+	modulePath, err := gomodule.ModulePath(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.mod: %s", err)
+	}
+
+	fset := token.NewFileSet()
+
+	l := &loader{
+		fset:       fset,
+		root:       dir,
+		modulePath: modulePath,
+		std:        importer.ForCompiler(fset, "gc", nil),
+		packages:   make(map[string]*types.Package),
+		loading:    make(map[string]bool),
+	}
+
+	rels, err := l.packageDirs()
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs := make(map[string]*types.Package)
+
+	for _, rel := range rels {
+		pkg, err := l.load(l.importPath(rel))
+		if err != nil {
+			return nil, err
+		}
+
+		if pkg == nil || pkg.Name() == "main" || isInternal(rel) {
+			continue
+		}
+
+		pkgs[rel] = pkg
+	}
+
+	return pkgs, nil
+}
+
+// packageDirs returns the dirs of the module containing Go files, skipping
+// testdata, vendor, hidden dirs and nested modules.
+func (l *loader) packageDirs() ([]string, error) {
+	var rels []string
+
+	err := filepath.WalkDir(l.root, func(fp string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(l.root, fp)
+		if err != nil {
+			return err
+		}
+
+		if rel != "." {
+			name := d.Name()
+			if name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+
+			if _, err := os.Stat(filepath.Join(fp, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+		}
+
+		rels = append(rels, filepath.ToSlash(rel))
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %s", l.root, err)
+	}
+
+	return rels, nil
+}
+
+// Import implements types.Importer.
+func (l *loader) Import(importPath string) (*types.Package, error) {
+	if rel, ok := l.relPath(importPath); ok {
+		pkg, err := l.load(importPath)
+		if err != nil {
+			return nil, err
+		}
+
+		if pkg == nil {
+			return nil, fmt.Errorf("package %s not found in %s", importPath, rel)
+		}
+
+		return pkg, nil
+	}
+
+	if isStd(importPath) {
+		if pkg, err := l.std.Import(importPath); err == nil {
+			return pkg, nil
+		}
+	}
+
+	pkg := types.NewPackage(importPath, path.Base(importPath))
+	pkg.MarkComplete()
+
+	return pkg, nil
+}
+
+// load type checks the package of the module import path. It returns nil if
+// the dir has no Go files for the current build context.
+func (l *loader) load(importPath string) (*types.Package, error) {
+	if pkg, ok := l.packages[importPath]; ok {
+		return pkg, nil
+	}
+
+	if l.loading[importPath] {
+		return nil, fmt.Errorf("import cycle at %s", importPath)
+	}
+
+	l.loading[importPath] = true
+	defer delete(l.loading, importPath)
+
+	rel, _ := l.relPath(importPath)
+	dir := filepath.Join(l.root, filepath.FromSlash(rel))
+
+	buildPkg, err := build.ImportDir(dir, 0)
+	if err != nil {
+		var noGo *build.NoGoError
+		if errors.As(err, &noGo) {
+			l.packages[importPath] = nil
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("failed to read package %s: %s", importPath, err)
+	}
+
+	files := make([]*ast.File, 0, len(buildPkg.GoFiles))
+
+	for _, name := range buildPkg.GoFiles {
+		file, err := parser.ParseFile(l.fset, filepath.Join(dir, name), nil, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %s", filepath.Join(rel, name), err)
+		}
+
+		files = append(files, file)
+	}
+
+	config := types.Config{
+		Importer:    l,
+		FakeImportC: true,
+		Error:       func(error) {},
+	}
+
+	// errors are reported to the config, and the package is checked as far as possible
+	pkg, _ := config.Check(importPath, l.fset, files, nil)
+
+	l.packages[importPath] = pkg
+
+	return pkg, nil
+}
+
+// relPath returns the path relative to the module root of an import path of
+// the module.
+func (l *loader) relPath(importPath string) (string, bool) {
+	if importPath == l.modulePath {
+		return ".", true
+	}
+
+	rel, ok := strings.CutPrefix(importPath, l.modulePath+"/")
+
+	return rel, ok
+}
+
+func (l *loader) importPath(rel string) string {
+	if rel == "." {
+		return l.modulePath
+	}
+
+	return l.modulePath + "/" + rel
+}
+
+// isStd returns true if the import path belongs to the standard library,
+// whose first element has no dot.
+func isStd(importPath string) bool {
+	first, _, _ := strings.Cut(importPath, "/")
+
+	return !strings.Contains(first, ".")
+}
+
+func isInternal(rel string) bool {
+	for _, elem := range strings.Split(rel, "/") {
+		if elem == "internal" {
+			return true
+		}
+	}
+
+	return false
+}
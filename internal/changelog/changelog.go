@@ -0,0 +1,243 @@
+package changelog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/gandarez/semver-action/internal/conventional"
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/regex"
+)
+
+// Category is the bump category a change belongs to.
+type Category string
+
+// Categories in the order they are rendered.
+const (
+	Major  Category = "major"
+	Minor  Category = "minor"
+	Patch  Category = "patch"
+	Hotfix Category = "hotfix"
+	Build  Category = "build"
+	Other  Category = "other"
+)
+
+// nolint: gochecknoglobals
+var (
+	categories = []Category{Major, Minor, Patch, Hotfix, Build, Other}
+	titles     = map[Category]string{
+		Major:  "Breaking Changes",
+		Minor:  "Features",
+		Patch:  "Bug Fixes",
+		Hotfix: "Hotfixes",
+		Build:  "Maintenance",
+		Other:  "Other Changes",
+	}
+	// squashPRRegex matches the pull request number GitHub appends to squash commits.
+	squashPRRegex = regexp.MustCompile(`\s*\(#(?P<number>[0-9]+)\)$`)
+	headerRegex   = regexp.MustCompile(`(?i)^# changelog\s*\n+`)
+)
+
+type (
+	// Config contains the configuration used to categorize commits.
+	Config struct {
+		MergeFormat   string
+		PatchPattern  regex.Regex
+		MinorPattern  regex.Regex
+		MajorPattern  regex.Regex
+		BuildPattern  regex.Regex
+		HotfixPattern regex.Regex
+	}
+
+	// Entry is a single change of the changelog.
+	Entry struct {
+		Hash         string
+		Subject      string
+		SourceBranch string
+		PRNumber     int
+		Category     Category
+	}
+
+	// Changelog contains the changes between two tags.
+	Changelog struct {
+		Tag     string
+		Entries []Entry
+	}
+)
+
+// New creates a changelog for tag from the commits, newest first, since the previous tag.
+func New(tag string, commits []git.Commit, config Config) Changelog {
+	entries := make([]Entry, 0, len(commits))
+
+	for _, commit := range commits {
+		entries = append(entries, newEntry(commit, config))
+	}
+
+	return Changelog{
+		Tag:     tag,
+		Entries: entries,
+	}
+}
+
+func newEntry(commit git.Commit, config Config) Entry {
+	header, body, _ := strings.Cut(commit.Message, "\n")
+
+	entry := Entry{
+		Hash:    commit.Hash,
+		Subject: strings.TrimSpace(header),
+	}
+
+	// merge commits are categorized by the source branch patterns
+	if merge, err := git.ParseMergeMessage(config.MergeFormat, commit.Message); err == nil {
+		entry.SourceBranch = merge.SourceBranch
+		entry.PRNumber = merge.PRNumber
+		entry.Category = config.categorize(merge.SourceBranch)
+		entry.Subject = firstLine(body, merge.SourceBranch)
+
+		return entry
+	}
+
+	if match := squashPRRegex.FindStringSubmatch(entry.Subject); match != nil {
+		entry.PRNumber = atoi(match[squashPRRegex.SubexpIndex("number")])
+		entry.Subject = squashPRRegex.ReplaceAllString(entry.Subject, "")
+	}
+
+	if _, ok := conventional.Parse(commit.Message); !ok {
+		entry.Category = Other
+
+		return entry
+	}
+
+	switch conventional.Level(commit.Message) {
+	case "major":
+		entry.Category = Major
+	case "minor":
+		entry.Category = Minor
+	case "patch":
+		entry.Category = Patch
+	default:
+		entry.Category = Build
+	}
+
+	return entry
+}
+
+// categorize returns the category of the first pattern matching the source branch.
+func (c Config) categorize(sourceBranch string) Category {
+	switch {
+	case matches(c.PatchPattern, sourceBranch):
+		return Patch
+	case matches(c.MinorPattern, sourceBranch):
+		return Minor
+	case matches(c.MajorPattern, sourceBranch):
+		return Major
+	case matches(c.BuildPattern, sourceBranch):
+		return Build
+	case matches(c.HotfixPattern, sourceBranch):
+		return Hotfix
+	default:
+		return Other
+	}
+}
+
+// Markdown renders the changelog as Markdown, grouping entries by category.
+func (c Changelog) Markdown() string {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "## %s\n", c.Tag)
+
+	if len(c.Entries) == 0 {
+		buf.WriteString("\nNo changes.\n")
+
+		return buf.String()
+	}
+
+	for _, category := range categories {
+		var lines []string
+
+		for _, entry := range c.Entries {
+			if entry.Category == category {
+				lines = append(lines, "- "+entry.line())
+			}
+		}
+
+		if len(lines) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(&buf, "\n### %s\n\n%s\n", titles[category], strings.Join(lines, "\n"))
+	}
+
+	return buf.String()
+}
+
+func (e Entry) line() string {
+	if e.PRNumber > 0 {
+		return fmt.Sprintf("%s (#%d)", e.Subject, e.PRNumber)
+	}
+
+	hash := e.Hash
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+
+	if hash == "" {
+		return e.Subject
+	}
+
+	return fmt.Sprintf("%s (%s)", e.Subject, hash)
+}
+
+// Prepend writes markdown at the top of the changelog file, right after its
+// `# Changelog` header if present. The file is created if it does not exist.
+func Prepend(fp, markdown string) error {
+	data, err := os.ReadFile(fp) // nolint:gosec
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read changelog file: %s", err)
+	}
+
+	content := string(data)
+
+	var header string
+
+	if loc := headerRegex.FindStringIndex(content); loc != nil {
+		header, content = content[:loc[1]], content[loc[1]:]
+	}
+
+	updated := header + strings.TrimRight(markdown, "\n") + "\n"
+	if content != "" {
+		updated += "\n" + content
+	}
+
+	if err := os.WriteFile(fp, []byte(updated), 0644); err != nil { // nolint:gosec
+		return fmt.Errorf("failed to write changelog file: %s", err)
+	}
+
+	return nil
+}
+
+func matches(rgx regex.Regex, s string) bool {
+	return rgx != nil && rgx.MatchString(s)
+}
+
+// firstLine returns the first non empty line of text or fallback if none.
+func firstLine(text, fallback string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			return line
+		}
+	}
+
+	return fallback
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,136 @@
+package changelog_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gandarez/semver-action/internal/changelog"
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/regex"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	commits := []git.Commit{
+		{Hash: "a1b2c3d4e5", Message: "Merge pull request #15 from gandarez/feature/new-api\n\nAdd new api"},
+		{Hash: "b1b2c3d4e5", Message: "Merge pull request #14 from gandarez/bugfix/some\n\nFix some bug"},
+		{Hash: "c1b2c3d4e5", Message: "Merge pull request #13 from gandarez/release/v2\n\nDrop old api"},
+		{Hash: "d1b2c3d4e5", Message: "Merge pull request #12 from gandarez/docs/readme"},
+		{Hash: "e1b2c3d4e5", Message: "Merge pull request #11 from gandarez/hotfix/crash\n\nFix crash"},
+		{Hash: "f1b2c3d4e5", Message: "feat(api): add endpoint (#10)"},
+		{Hash: "a2b2c3d4e5", Message: "fix!: change default\n\nSome body"},
+		{Hash: "b2b2c3d4e5", Message: "chore: bump deps"},
+		{Hash: "c2b2c3d4e5", Message: "update readme"},
+	}
+
+	cl := changelog.New("v2.0.0", commits, changelog.Config{
+		MergeFormat:   "auto",
+		PatchPattern:  regex.MustCompile(`(?i)^(.+:)?(bugfix/.+)`),
+		MinorPattern:  regex.MustCompile(`(?i)^(.+:)?(feature/.+)`),
+		MajorPattern:  regex.MustCompile(`(?i)^(.+:)?(release/.+)`),
+		BuildPattern:  regex.MustCompile(`(?i)^(.+:)?((doc(s)?|misc)/.+)`),
+		HotfixPattern: regex.MustCompile(`(?i)^(.+:)?(hotfix/.+)`),
+	})
+
+	assert.Equal(t, changelog.Changelog{
+		Tag: "v2.0.0",
+		Entries: []changelog.Entry{
+			{Hash: "a1b2c3d4e5", Subject: "Add new api", SourceBranch: "feature/new-api", PRNumber: 15, Category: changelog.Minor},
+			{Hash: "b1b2c3d4e5", Subject: "Fix some bug", SourceBranch: "bugfix/some", PRNumber: 14, Category: changelog.Patch},
+			{Hash: "c1b2c3d4e5", Subject: "Drop old api", SourceBranch: "release/v2", PRNumber: 13, Category: changelog.Major},
+			{Hash: "d1b2c3d4e5", Subject: "docs/readme", SourceBranch: "docs/readme", PRNumber: 12, Category: changelog.Build},
+			{Hash: "e1b2c3d4e5", Subject: "Fix crash", SourceBranch: "hotfix/crash", PRNumber: 11, Category: changelog.Hotfix},
+			{Hash: "f1b2c3d4e5", Subject: "feat(api): add endpoint", PRNumber: 10, Category: changelog.Minor},
+			{Hash: "a2b2c3d4e5", Subject: "fix!: change default", Category: changelog.Major},
+			{Hash: "b2b2c3d4e5", Subject: "chore: bump deps", Category: changelog.Build},
+			{Hash: "c2b2c3d4e5", Subject: "update readme", Category: changelog.Other},
+		},
+	}, cl)
+
+	assert.Equal(t, `## v2.0.0
+
+### Breaking Changes
+
+- Drop old api (#13)
+- fix!: change default (a2b2c3d)
+
+### Features
+
+- Add new api (#15)
+- feat(api): add endpoint (#10)
+
+### Bug Fixes
+
+- Fix some bug (#14)
+
+### Hotfixes
+
+- Fix crash (#11)
+
+### Maintenance
+
+- docs/readme (#12)
+- chore: bump deps (b2b2c3d)
+
+### Other Changes
+
+- update readme (c2b2c3d)
+`, cl.Markdown())
+}
+
+func TestMarkdown_NoChanges(t *testing.T) {
+	cl := changelog.New("v1.0.1", nil, changelog.Config{})
+
+	assert.Equal(t, "## v1.0.1\n\nNo changes.\n", cl.Markdown())
+}
+
+func TestPrepend(t *testing.T) {
+	tests := map[string]struct {
+		Current  string
+		Expected string
+	}{
+		"with header": {
+			Current:  "# Changelog\n\n## v1.0.0\n\n- Initial\n",
+			Expected: "# Changelog\n\n## v1.1.0\n\n- New\n\n## v1.0.0\n\n- Initial\n",
+		},
+		"without header": {
+			Current:  "## v1.0.0\n\n- Initial\n",
+			Expected: "## v1.1.0\n\n- New\n\n## v1.0.0\n\n- Initial\n",
+		},
+		"empty file": {
+			Current:  "",
+			Expected: "## v1.1.0\n\n- New\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			fp := filepath.Join(t.TempDir(), "CHANGELOG.md")
+
+			require.NoError(t, os.WriteFile(fp, []byte(test.Current), 0600))
+
+			err := changelog.Prepend(fp, "## v1.1.0\n\n- New\n")
+			require.NoError(t, err)
+
+			data, err := os.ReadFile(fp)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, string(data))
+		})
+	}
+}
+
+func TestPrepend_NewFile(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "CHANGELOG.md")
+
+	err := changelog.Prepend(fp, "## v1.1.0\n\n- New\n")
+	require.NoError(t, err)
+
+	data, err := os.ReadFile(fp)
+	require.NoError(t, err)
+
+	assert.Equal(t, "## v1.1.0\n\n- New\n", string(data))
+}
@@ -0,0 +1,97 @@
+package conventional
+
+import (
+	"regexp"
+	"strings"
+)
+
+// nolint: gochecknoglobals
+var (
+	headerRegex         = regexp.MustCompile(`^(?P<type>[a-zA-Z]+)(?:\((?P<scope>[^()]*)\))?(?P<breaking>!)?: (?P<description>.+)$`)
+	breakingFooterRegex = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE: `)
+)
+
+// Commit contains the parsed parts of a Conventional Commits message.
+type Commit struct {
+	Type        string
+	Scope       string
+	Description string
+	Breaking    bool
+}
+
+// Parse parses a commit message following the Conventional Commits specification.
+// It returns false if the message header does not follow the specification.
+func Parse(message string) (Commit, bool) {
+	header, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+
+	match := headerRegex.FindStringSubmatch(strings.TrimSpace(header))
+	if match == nil {
+		return Commit{}, false
+	}
+
+	commit := Commit{
+		Type:        strings.ToLower(match[headerRegex.SubexpIndex("type")]),
+		Scope:       match[headerRegex.SubexpIndex("scope")],
+		Description: match[headerRegex.SubexpIndex("description")],
+		Breaking:    match[headerRegex.SubexpIndex("breaking")] != "",
+	}
+
+	if breakingFooterRegex.MatchString(body) {
+		commit.Breaking = true
+	}
+
+	return commit, true
+}
+
+// Level returns the version part to bump for a single commit message.
+// It returns "major", "minor", "patch" or empty if the message does not require a bump.
+func Level(message string) string {
+	commit, ok := Parse(message)
+	if !ok {
+		return ""
+	}
+
+	switch {
+	case commit.Breaking:
+		return "major"
+	case commit.Type == "feat":
+		return "minor"
+	case commit.Type == "fix":
+		return "patch"
+	default:
+		return ""
+	}
+}
+
+// Bump returns the highest version part to bump among all commit messages.
+// It returns "major", "minor", "patch" or empty if none of them requires a bump.
+func Bump(messages ...string) string {
+	var highest string
+
+	for _, message := range messages {
+		level := Level(message)
+
+		if rank(level) > rank(highest) {
+			highest = level
+		}
+
+		if highest == "major" {
+			break
+		}
+	}
+
+	return highest
+}
+
+func rank(level string) int {
+	switch level {
+	case "major":
+		return 3
+	case "minor":
+		return 2
+	case "patch":
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,127 @@
+package conventional_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/semver-action/internal/conventional"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		Message  string
+		Expected conventional.Commit
+	}{
+		"type only": {
+			Message: "fix: some bug",
+			Expected: conventional.Commit{
+				Type:        "fix",
+				Description: "some bug",
+			},
+		},
+		"type and scope": {
+			Message: "feat(api): new endpoint",
+			Expected: conventional.Commit{
+				Type:        "feat",
+				Scope:       "api",
+				Description: "new endpoint",
+			},
+		},
+		"breaking marker": {
+			Message: "refactor(api)!: drop old endpoint",
+			Expected: conventional.Commit{
+				Type:        "refactor",
+				Scope:       "api",
+				Description: "drop old endpoint",
+				Breaking:    true,
+			},
+		},
+		"breaking footer": {
+			Message: "feat: new endpoint\n\nSome body.\n\nBREAKING CHANGE: old endpoint removed",
+			Expected: conventional.Commit{
+				Type:        "feat",
+				Description: "new endpoint",
+				Breaking:    true,
+			},
+		},
+		"breaking footer with hyphen": {
+			Message: "fix: some bug\n\nBREAKING-CHANGE: behaviour changed",
+			Expected: conventional.Commit{
+				Type:        "fix",
+				Description: "some bug",
+				Breaking:    true,
+			},
+		},
+		"upper case type": {
+			Message: "FEAT: new endpoint",
+			Expected: conventional.Commit{
+				Type:        "feat",
+				Description: "new endpoint",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			commit, ok := conventional.Parse(test.Message)
+
+			assert.True(t, ok)
+			assert.Equal(t, test.Expected, commit)
+		})
+	}
+}
+
+func TestParse_NotConventional(t *testing.T) {
+	tests := map[string]string{
+		"plain message":      "update readme",
+		"missing space":      "fix:some bug",
+		"merge pull request": "Merge pull request #123 from gandarez/feature/semver-initial",
+		"footer only":        "update readme\n\nBREAKING CHANGE: not a header",
+	}
+
+	for name, message := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, ok := conventional.Parse(message)
+
+			assert.False(t, ok)
+		})
+	}
+}
+
+func TestBump(t *testing.T) {
+	tests := map[string]struct {
+		Messages []string
+		Expected string
+	}{
+		"no commits": {
+			Expected: "",
+		},
+		"no relevant commits": {
+			Messages: []string{"docs: update readme", "chore: bump deps", "update readme"},
+			Expected: "",
+		},
+		"fix": {
+			Messages: []string{"docs: update readme", "fix: some bug"},
+			Expected: "patch",
+		},
+		"feat and fix": {
+			Messages: []string{"fix: some bug", "feat: new endpoint", "fix: other bug"},
+			Expected: "minor",
+		},
+		"breaking marker": {
+			Messages: []string{"fix: some bug", "chore!: drop go 1.20", "feat: new endpoint"},
+			Expected: "major",
+		},
+		"breaking footer": {
+			Messages: []string{"fix: some bug\n\nBREAKING CHANGE: behaviour changed"},
+			Expected: "major",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, conventional.Bump(test.Messages...))
+		})
+	}
+}
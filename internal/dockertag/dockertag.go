@@ -0,0 +1,112 @@
+package dockertag
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver/v4"
+)
+
+// Kind is a docker tag rendered from the version.
+type Kind string
+
+const (
+	// Version is the full version, e.g. 1.4.2 or 1.4.2-pre.3.
+	Version Kind = "version"
+	// Minor is the floating major and minor tag of a release, e.g. 1.4.
+	Minor Kind = "minor"
+	// Major is the floating major tag of a release, e.g. 1.
+	Major Kind = "major"
+	// Latest is the floating tag of the highest release.
+	Latest Kind = "latest"
+	// Edge is the floating tag of the latest prerelease or build.
+	Edge Kind = "edge"
+	// Sha is the short commit sha, e.g. sha-2f08f7b.
+	Sha Kind = "sha"
+)
+
+const shortShaLength = 7
+
+// Config contains the configuration used to render the tags.
+type Config struct {
+	Kinds []Kind
+	// Image is prepended to every tag followed by a colon, e.g. ghcr.io/owner/app.
+	Image string
+	// CommitSha is rendered by Sha. It's skipped when empty.
+	CommitSha string
+	// Releases contains the final versions existing in the repo. Floating tags
+	// are not rendered when a higher release exists in their line.
+	Releases []semver.Version
+}
+
+// Kinds returns all valid kinds.
+func Kinds() []Kind {
+	return []Kind{Version, Minor, Major, Latest, Edge, Sha}
+}
+
+// Render returns the docker tags of the version in the order of the kinds.
+// Releases get the version and its floating minor, major and latest tags,
+// while prereleases and builds only get the version and edge.
+func Render(version semver.Version, config Config) []string {
+	var (
+		tags    []string
+		release = len(version.Pre) == 0 && len(version.Build) == 0
+	)
+
+	for _, kind := range config.Kinds {
+		var tag string
+
+		switch kind {
+		case Version:
+			// build metadata separator is not allowed in docker tags
+			tag = strings.ReplaceAll(version.String(), "+", "-")
+		case Minor:
+			if release && isHighest(version, config.Releases, func(r semver.Version) bool {
+				return r.Major == version.Major && r.Minor == version.Minor
+			}) {
+				tag = fmt.Sprintf("%d.%d", version.Major, version.Minor)
+			}
+		case Major:
+			if release && isHighest(version, config.Releases, func(r semver.Version) bool {
+				return r.Major == version.Major
+			}) {
+				tag = fmt.Sprintf("%d", version.Major)
+			}
+		case Latest:
+			if release && isHighest(version, config.Releases, func(semver.Version) bool { return true }) {
+				tag = string(Latest)
+			}
+		case Edge:
+			if !release {
+				tag = string(Edge)
+			}
+		case Sha:
+			if config.CommitSha != "" {
+				tag = "sha-" + config.CommitSha[:min(len(config.CommitSha), shortShaLength)]
+			}
+		}
+
+		if tag == "" {
+			continue
+		}
+
+		if config.Image != "" {
+			tag = config.Image + ":" + tag
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags
+}
+
+// isHighest returns true if no release in the same line is higher than version.
+func isHighest(version semver.Version, releases []semver.Version, sameLine func(semver.Version) bool) bool {
+	for _, release := range releases {
+		if len(release.Pre) == 0 && sameLine(release) && release.GT(version) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,99 @@
+package dockertag_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/semver-action/internal/dockertag"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRender(t *testing.T) {
+	allKinds := []dockertag.Kind{
+		dockertag.Version,
+		dockertag.Minor,
+		dockertag.Major,
+		dockertag.Latest,
+		dockertag.Edge,
+	}
+
+	tests := map[string]struct {
+		Version  string
+		Config   dockertag.Config
+		Expected []string
+	}{
+		"release": {
+			Version:  "1.4.2",
+			Config:   dockertag.Config{Kinds: allKinds},
+			Expected: []string{"1.4.2", "1.4", "1", "latest"},
+		},
+		"prerelease": {
+			Version:  "1.4.2-pre.3",
+			Config:   dockertag.Config{Kinds: allKinds},
+			Expected: []string{"1.4.2-pre.3", "edge"},
+		},
+		"build": {
+			Version:  "1.4.2+5",
+			Config:   dockertag.Config{Kinds: allKinds},
+			Expected: []string{"1.4.2-5", "edge"},
+		},
+		"image and sha": {
+			Version: "1.4.2",
+			Config: dockertag.Config{
+				Kinds:     []dockertag.Kind{dockertag.Version, dockertag.Latest, dockertag.Sha},
+				Image:     "ghcr.io/owner/app",
+				CommitSha: "2f08f7b455ec64741d135216d19d7e0c4dd46458",
+			},
+			Expected: []string{"ghcr.io/owner/app:1.4.2", "ghcr.io/owner/app:latest", "ghcr.io/owner/app:sha-2f08f7b"},
+		},
+		"sha without commit sha": {
+			Version:  "1.4.2",
+			Config:   dockertag.Config{Kinds: []dockertag.Kind{dockertag.Version, dockertag.Sha}},
+			Expected: []string{"1.4.2"},
+		},
+		"maintenance release of older minor": {
+			Version: "1.3.5",
+			Config: dockertag.Config{
+				Kinds:    allKinds,
+				Releases: releases(t, "1.3.4", "1.4.2", "2.0.0-pre.1"),
+			},
+			Expected: []string{"1.3.5", "1.3"},
+		},
+		"maintenance release of older major": {
+			Version: "1.4.3",
+			Config: dockertag.Config{
+				Kinds:    allKinds,
+				Releases: releases(t, "1.4.2", "2.0.0"),
+			},
+			Expected: []string{"1.4.3", "1.4", "1"},
+		},
+		"higher prerelease does not suppress": {
+			Version: "1.4.3",
+			Config: dockertag.Config{
+				Kinds:    allKinds,
+				Releases: releases(t, "1.4.2", "1.5.0-pre.1"),
+			},
+			Expected: []string{"1.4.3", "1.4", "1", "latest"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tags := dockertag.Render(semver.MustParse(test.Version), test.Config)
+
+			assert.Equal(t, test.Expected, tags)
+		})
+	}
+}
+
+func releases(t *testing.T, versions ...string) []semver.Version {
+	t.Helper()
+
+	parsed := make([]semver.Version, len(versions))
+	for i, v := range versions {
+		parsed[i] = semver.MustParse(v)
+	}
+
+	return parsed
+}
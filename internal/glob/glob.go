@@ -0,0 +1,120 @@
+package glob
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Glob is a compiled path glob pattern.
+type Glob struct {
+	pattern string
+	rgx     *regexp.Regexp
+}
+
+// Compile compiles a path glob pattern. Besides the path.Match syntax
+// (`*`, `?` and `[...]`), `**` matches any number of directories.
+// A pattern ending with `/` matches everything below that directory.
+func Compile(pattern string) (Glob, error) {
+	if pattern == "" {
+		return Glob{}, fmt.Errorf("empty glob pattern")
+	}
+
+	expr := pattern
+	if strings.HasSuffix(expr, "/") {
+		expr += "**"
+	}
+
+	var sb strings.Builder
+
+	sb.WriteString("^")
+
+	for i := 0; i < len(expr); i++ {
+		c := expr[i]
+
+		switch c {
+		case '*':
+			if i+1 < len(expr) && expr[i+1] == '*' {
+				i++
+
+				// `**/` matches zero or more directories
+				if i+1 < len(expr) && expr[i+1] == '/' {
+					i++
+
+					sb.WriteString("(?:.*/)?")
+
+					continue
+				}
+
+				sb.WriteString(".*")
+
+				continue
+			}
+
+			sb.WriteString("[^/]*")
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(expr[i:], ']')
+			if end < 0 {
+				return Glob{}, fmt.Errorf("invalid glob pattern %q: missing ]", pattern)
+			}
+
+			class := expr[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+
+			sb.WriteString("[" + class + "]")
+
+			i += end
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	sb.WriteString("$")
+
+	rgx, err := regexp.Compile(sb.String())
+	if err != nil {
+		return Glob{}, fmt.Errorf("invalid glob pattern %q: %s", pattern, err)
+	}
+
+	return Glob{
+		pattern: pattern,
+		rgx:     rgx,
+	}, nil
+}
+
+// MustCompile compiles a path glob pattern and panics upon failure.
+func MustCompile(pattern string) Glob {
+	g, err := Compile(pattern)
+	if err != nil {
+		panic(err)
+	}
+
+	return g
+}
+
+// Match reports whether the slash separated path matches the glob.
+func (g Glob) Match(path string) bool {
+	return g.rgx.MatchString(strings.TrimPrefix(path, "./"))
+}
+
+// String returns the source pattern.
+func (g Glob) String() string {
+	return g.pattern
+}
+
+// MatchAny reports whether any of the paths matches any of the globs.
+func MatchAny(globs []Glob, paths []string) bool {
+	for _, path := range paths {
+		for _, g := range globs {
+			if g.Match(path) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,65 @@
+package glob_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/semver-action/internal/glob"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	tests := map[string]struct {
+		Pattern  string
+		Path     string
+		Expected bool
+	}{
+		"exact":                         {Pattern: "go.mod", Path: "go.mod", Expected: true},
+		"exact mismatch":                {Pattern: "go.mod", Path: "sub/go.mod", Expected: false},
+		"star":                          {Pattern: "docs/*.md", Path: "docs/README.md", Expected: true},
+		"star does not cross directory": {Pattern: "docs/*.md", Path: "docs/api/README.md", Expected: false},
+		"double star":                   {Pattern: "services/api/**", Path: "services/api/cmd/main.go", Expected: true},
+		"double star directory":         {Pattern: "services/api/**", Path: "services/web/main.go", Expected: false},
+		"double star prefix":            {Pattern: "**/*_test.go", Path: "pkg/git/git_test.go", Expected: true},
+		"double star prefix root":       {Pattern: "**/*_test.go", Path: "main_test.go", Expected: true},
+		"double star middle":            {Pattern: "services/**/Dockerfile", Path: "services/api/Dockerfile", Expected: true},
+		"trailing slash":                {Pattern: "docs/", Path: "docs/api/index.md", Expected: true},
+		"question mark":                 {Pattern: "v?.txt", Path: "v1.txt", Expected: true},
+		"class":                         {Pattern: "[abc].go", Path: "b.go", Expected: true},
+		"negated class":                 {Pattern: "[!abc].go", Path: "b.go", Expected: false},
+		"dot is literal":                {Pattern: "go.mod", Path: "goxmod", Expected: false},
+		"leading dot slash":             {Pattern: "docs/**", Path: "./docs/index.md", Expected: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			g, err := glob.Compile(test.Pattern)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, g.Match(test.Path))
+		})
+	}
+}
+
+func TestCompile_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":          "",
+		"unclosed class": "docs/[a",
+	}
+
+	for name, pattern := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := glob.Compile(pattern)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestMatchAny(t *testing.T) {
+	globs := []glob.Glob{glob.MustCompile("services/api/**"), glob.MustCompile("libs/shared/**")}
+
+	assert.True(t, glob.MatchAny(globs, []string{"README.md", "libs/shared/util.go"}))
+	assert.False(t, glob.MatchAny(globs, []string{"README.md", "services/web/main.go"}))
+	assert.False(t, glob.MatchAny(globs, nil))
+}
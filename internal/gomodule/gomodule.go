@@ -0,0 +1,78 @@
+package gomodule
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrModuleNotFound is returned when go.mod has no module directive.
+var ErrModuleNotFound = errors.New("module directive not found")
+
+// ModulePath returns the module path declared by the go.mod content.
+func ModulePath(data []byte) (string, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if i := strings.Index(line, "//"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != "module" {
+			continue
+		}
+
+		path := fields[1]
+
+		if unquoted, err := strconv.Unquote(path); err == nil {
+			path = unquoted
+		}
+
+		return path, nil
+	}
+
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	return "", ErrModuleNotFound
+}
+
+// PathMajor returns the major version of the /vN suffix of the module path,
+// or zero if it has none. Suffixes lower than v2 are not major versions.
+func PathMajor(path string) uint64 {
+	last := path[strings.LastIndex(path, "/")+1:]
+
+	if !strings.HasPrefix(last, "v") {
+		return 0
+	}
+
+	major, err := strconv.ParseUint(last[1:], 10, 64)
+	if err != nil || major < 2 || strconv.FormatUint(major, 10) != last[1:] {
+		return 0
+	}
+
+	return major
+}
+
+// CheckMajor returns an error if the module path suffix does not match the
+// major version. Versions v2 and later require the /vN suffix, while v0 and
+// v1 require none.
+func CheckMajor(path string, major uint64) error {
+	pathMajor := PathMajor(path)
+
+	switch {
+	case major < 2 && pathMajor != 0:
+		return fmt.Errorf("module path %s must not end with /v%d for major version %d", path, pathMajor, major)
+	case major >= 2 && pathMajor != major:
+		return fmt.Errorf("module path %s must end with /v%d for major version %d", path, major, major)
+	default:
+		return nil
+	}
+}
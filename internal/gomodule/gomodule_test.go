@@ -0,0 +1,111 @@
+package gomodule_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/semver-action/internal/gomodule"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModulePath(t *testing.T) {
+	tests := map[string]struct {
+		Content  string
+		Expected string
+	}{
+		"module": {
+			Content:  "module github.com/owner/lib\n\ngo 1.22\n",
+			Expected: "github.com/owner/lib",
+		},
+		"major suffix": {
+			Content:  "// Deprecated: use v3\nmodule github.com/owner/lib/v2 // v2 line\n",
+			Expected: "github.com/owner/lib/v2",
+		},
+		"quoted": {
+			Content:  "module \"github.com/owner/lib\"\n",
+			Expected: "github.com/owner/lib",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path, err := gomodule.ModulePath([]byte(test.Content))
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, path)
+		})
+	}
+}
+
+func TestModulePath_NotFound(t *testing.T) {
+	_, err := gomodule.ModulePath([]byte("go 1.22\n"))
+
+	assert.ErrorIs(t, err, gomodule.ErrModuleNotFound)
+}
+
+func TestPathMajor(t *testing.T) {
+	tests := map[string]uint64{
+		"github.com/owner/lib":         0,
+		"github.com/owner/lib/v2":      2,
+		"github.com/owner/lib/v10":     10,
+		"github.com/owner/lib/v1":      0,
+		"github.com/owner/lib/v02":     0,
+		"github.com/owner/lib/vendor":  0,
+		"github.com/owner/lib/sub/v3":  3,
+		"github.com/owner/v2/internal": 0,
+	}
+
+	for path, expected := range tests {
+		t.Run(path, func(t *testing.T) {
+			assert.Equal(t, expected, gomodule.PathMajor(path))
+		})
+	}
+}
+
+func TestCheckMajor(t *testing.T) {
+	tests := map[string]struct {
+		Path     string
+		Major    uint64
+		Expected string
+	}{
+		"v1 without suffix": {
+			Path:  "github.com/owner/lib",
+			Major: 1,
+		},
+		"v2 with suffix": {
+			Path:  "github.com/owner/lib/v2",
+			Major: 2,
+		},
+		"v2 without suffix": {
+			Path:     "github.com/owner/lib",
+			Major:    2,
+			Expected: "module path github.com/owner/lib must end with /v2 for major version 2",
+		},
+		"v3 with v2 suffix": {
+			Path:     "github.com/owner/lib/v2",
+			Major:    3,
+			Expected: "module path github.com/owner/lib/v2 must end with /v3 for major version 3",
+		},
+		"v1 with suffix": {
+			Path:     "github.com/owner/lib/v2",
+			Major:    1,
+			Expected: "module path github.com/owner/lib/v2 must not end with /v2 for major version 1",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := gomodule.CheckMajor(test.Path, test.Major)
+
+			if test.Expected == "" {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+
+			assert.Equal(t, test.Expected, err.Error())
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package versionfile
+
+import (
+	"bytes"
+	"regexp"
+)
+
+// nolint: gochecknoglobals
+var chartVersionRegex = regexp.MustCompile(`^(?:version|appVersion):[ \t]*(?:"([^"]*)"|'([^']*)'|([^\s"'#]+))`)
+
+// renderChart replaces the top-level version and appVersion keys keeping their quotes.
+func renderChart(data []byte, version string) ([]byte, error) {
+	var (
+		out   bytes.Buffer
+		found bool
+	)
+
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		loc := chartVersionRegex.FindSubmatchIndex(line)
+		if loc == nil {
+			out.Write(line)
+			continue
+		}
+
+		// one of double quoted, single quoted or plain value matched
+		start, end := loc[2], loc[3]
+		for i := 4; start < 0 && i < len(loc); i += 2 {
+			start, end = loc[i], loc[i+1]
+		}
+
+		out.Write(line[:start])
+		out.WriteString(version)
+		out.Write(line[end:])
+
+		found = true
+	}
+
+	if !found {
+		return nil, ErrVersionNotFound
+	}
+
+	return out.Bytes(), nil
+}
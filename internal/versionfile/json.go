@@ -0,0 +1,105 @@
+package versionfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// renderJSON replaces the value of the top-level "version" key.
+func renderJSON(data []byte, version string) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse json: %s", err)
+	}
+
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return nil, errors.New("json must be an object")
+	}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse json: %s", err)
+		}
+
+		key, _ := tok.(string)
+
+		if key != "version" {
+			if err := skipJSONValue(dec); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		value, err := dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse json: %s", err)
+		}
+
+		if _, ok := value.(string); !ok {
+			return nil, errors.New("version must be a string")
+		}
+
+		end := int(dec.InputOffset())
+
+		return replace(data, openingQuote(data, end), end, strconv.Quote(version)), nil
+	}
+
+	return nil, ErrVersionNotFound
+}
+
+// openingQuote returns the index of the opening quote of the string ending at end.
+func openingQuote(data []byte, end int) int {
+	for i := end - 2; i >= 0; i-- {
+		if data[i] != '"' {
+			continue
+		}
+
+		backslashes := 0
+		for j := i - 1; j >= 0 && data[j] == '\\'; j-- {
+			backslashes++
+		}
+
+		if backslashes%2 == 0 {
+			return i
+		}
+	}
+
+	return 0
+}
+
+// skipJSONValue consumes the next value including nested objects and arrays.
+func skipJSONValue(dec *json.Decoder) error {
+	depth := 0
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return errors.New("failed to parse json: unexpected end of file")
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to parse json: %s", err)
+		}
+
+		if delim, ok := tok.(json.Delim); ok {
+			switch delim {
+			case '{', '[':
+				depth++
+			case '}', ']':
+				depth--
+			}
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
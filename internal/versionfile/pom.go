@@ -0,0 +1,52 @@
+package versionfile
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// renderPom replaces the text of /project/version. The version of the parent
+// and of the dependencies are left untouched.
+func renderPom(data []byte, version string) ([]byte, error) {
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	var path []string
+
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil, ErrVersionNotFound
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse xml: %s", err)
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			path = append(path, t.Name.Local)
+
+			if len(path) != 2 || path[0] != "project" || path[1] != "version" {
+				continue
+			}
+
+			start := int(dec.InputOffset())
+
+			next, err := dec.Token()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse xml: %s", err)
+			}
+
+			if _, ok := next.(xml.CharData); !ok {
+				return nil, errors.New("version must have a value")
+			}
+
+			return replace(data, start, int(dec.InputOffset()), version), nil
+		case xml.EndElement:
+			path = path[:len(path)-1]
+		}
+	}
+}
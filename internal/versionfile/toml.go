@@ -0,0 +1,79 @@
+package versionfile
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// nolint: gochecknoglobals
+var (
+	tomlTableRegex   = regexp.MustCompile(`^\s*\[\s*([A-Za-z0-9_.\-"' ]+?)\s*\]\s*(#.*)?$`)
+	tomlVersionRegex = regexp.MustCompile(`^\s*version\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
+
+// renderTOML replaces the version key of the first of tables found. Only
+// string values are replaced, so `version.workspace = true` is left untouched.
+func renderTOML(data []byte, version string, tables ...string) ([]byte, error) {
+	var (
+		table  string
+		offset int
+	)
+
+	for _, line := range bytes.SplitAfter(data, []byte("\n")) {
+		lineStart := offset
+		offset += len(line)
+
+		trimmed := bytes.TrimRight(line, "\r\n")
+
+		// array of tables, e.g. [[bin]]
+		if bytes.HasPrefix(bytes.TrimSpace(trimmed), []byte("[[")) {
+			table = ""
+			continue
+		}
+
+		if match := tomlTableRegex.FindSubmatch(trimmed); match != nil {
+			table = normalizeTOMLKey(string(match[1]))
+			continue
+		}
+
+		if !stringInSlice(table, tables) {
+			continue
+		}
+
+		loc := tomlVersionRegex.FindSubmatchIndex(trimmed)
+		if loc == nil {
+			continue
+		}
+
+		// either the double or the single quoted group matched
+		start, end := loc[2], loc[3]
+		if start < 0 {
+			start, end = loc[4], loc[5]
+		}
+
+		return replace(data, lineStart+start, lineStart+end, version), nil
+	}
+
+	return nil, ErrVersionNotFound
+}
+
+// normalizeTOMLKey removes quotes and spaces around dotted keys.
+func normalizeTOMLKey(key string) string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"'`)
+	}
+
+	return strings.Join(parts, ".")
+}
+
+func stringInSlice(a string, list []string) bool {
+	for _, b := range list {
+		if b == a {
+			return true
+		}
+	}
+
+	return false
+}
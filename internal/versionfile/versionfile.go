@@ -0,0 +1,196 @@
+package versionfile
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// Type is the format of a version file.
+type Type string
+
+const (
+	// TypeJSON writes the top-level "version" of a JSON file such as package.json.
+	TypeJSON Type = "json"
+	// TypeCargo writes the version of the [package] or [workspace.package] table of Cargo.toml.
+	TypeCargo Type = "cargo"
+	// TypePyproject writes the version of the [project] or [tool.poetry] table of pyproject.toml.
+	TypePyproject Type = "pyproject"
+	// TypePom writes the version of the project element of pom.xml.
+	TypePom Type = "pom"
+	// TypeChart writes the version and appVersion of a Helm Chart.yaml.
+	TypeChart Type = "chart"
+	// TypePlain replaces the whole content of a file such as VERSION.
+	TypePlain Type = "plain"
+	// TypeRegex replaces the first capture group of every match of a pattern.
+	TypeRegex Type = "regex"
+)
+
+// ErrVersionNotFound is returned when the version is not found in the file.
+var ErrVersionNotFound = errors.New("version not found")
+
+// nolint: gochecknoglobals
+var typesByName = map[string]Type{
+	"package.json":   TypeJSON,
+	"Cargo.toml":     TypeCargo,
+	"pyproject.toml": TypePyproject,
+	"pom.xml":        TypePom,
+	"Chart.yaml":     TypeChart,
+	"VERSION":        TypePlain,
+}
+
+// Target is a file to write the version into.
+type Target struct {
+	Path string
+	Type Type
+	// Pattern is only used by TypeRegex and must have one capture group.
+	Pattern *regexp.Regexp
+	// Version is the version to write into the file.
+	Version string
+}
+
+// Detect returns the type of the file by its name.
+func Detect(path string) (Type, bool) {
+	t, ok := typesByName[filepath.Base(path)]
+	return t, ok
+}
+
+// Types returns all valid types.
+func Types() []Type {
+	return []Type{TypeJSON, TypeCargo, TypePyproject, TypePom, TypeChart, TypePlain, TypeRegex}
+}
+
+// Write writes the version of every target into it and returns the paths of
+// the files that changed. Relative paths are relative to dir. All files are
+// rendered before any is written, so nothing is written if one of them fails.
+func Write(dir string, targets []Target) ([]string, error) {
+	type rendered struct {
+		fp      string
+		path    string
+		content []byte
+		mode    os.FileMode
+	}
+
+	var files []rendered
+
+	for _, target := range targets {
+		fp := target.Path
+		if !filepath.IsAbs(fp) {
+			fp = filepath.Join(dir, fp)
+		}
+
+		info, err := os.Stat(fp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat version file: %s", err)
+		}
+
+		data, err := os.ReadFile(fp) // nolint:gosec
+		if err != nil {
+			return nil, fmt.Errorf("failed to read version file: %s", err)
+		}
+
+		content, err := Render(target, data, target.Version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write version into %s: %s", target.Path, err)
+		}
+
+		if bytes.Equal(data, content) {
+			continue
+		}
+
+		files = append(files, rendered{
+			fp:      fp,
+			path:    target.Path,
+			content: content,
+			mode:    info.Mode().Perm(),
+		})
+	}
+
+	changed := make([]string, 0, len(files))
+
+	for _, file := range files {
+		if err := os.WriteFile(file.fp, file.content, file.mode); err != nil {
+			return nil, fmt.Errorf("failed to write version file: %s", err)
+		}
+
+		changed = append(changed, file.path)
+	}
+
+	return changed, nil
+}
+
+// Render returns data with version written according to the target type.
+// Everything else in data is kept as is.
+func Render(target Target, data []byte, version string) ([]byte, error) {
+	switch target.Type {
+	case TypeJSON:
+		return renderJSON(data, version)
+	case TypeCargo:
+		return renderTOML(data, version, "package", "workspace.package")
+	case TypePyproject:
+		return renderTOML(data, version, "project", "tool.poetry")
+	case TypePom:
+		return renderPom(data, version)
+	case TypeChart:
+		return renderChart(data, version)
+	case TypePlain:
+		return renderPlain(data, version), nil
+	case TypeRegex:
+		return renderRegex(data, version, target.Pattern)
+	default:
+		return nil, fmt.Errorf("unknown version file type %q", target.Type)
+	}
+}
+
+// renderPlain replaces the whole content keeping the trailing newline.
+func renderPlain(data []byte, version string) []byte {
+	trimmed := bytes.TrimRight(data, "\r\n")
+
+	return append([]byte(version), data[len(trimmed):]...)
+}
+
+// renderRegex replaces the first capture group of every match.
+func renderRegex(data []byte, version string, pattern *regexp.Regexp) ([]byte, error) {
+	if pattern == nil || pattern.NumSubexp() < 1 {
+		return nil, errors.New("pattern must have one capture group")
+	}
+
+	matches := pattern.FindAllSubmatchIndex(data, -1)
+	if len(matches) == 0 {
+		return nil, ErrVersionNotFound
+	}
+
+	var (
+		out  bytes.Buffer
+		last int
+	)
+
+	for _, match := range matches {
+		start, end := match[2], match[3]
+		if start < 0 {
+			continue
+		}
+
+		out.Write(data[last:start])
+		out.WriteString(version)
+
+		last = end
+	}
+
+	out.Write(data[last:])
+
+	return out.Bytes(), nil
+}
+
+// replace replaces data[start:end] with value.
+func replace(data []byte, start, end int, value string) []byte {
+	out := make([]byte, 0, len(data)-(end-start)+len(value))
+	out = append(out, data[:start]...)
+	out = append(out, value...)
+	out = append(out, data[end:]...)
+
+	return out
+}
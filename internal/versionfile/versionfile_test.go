@@ -0,0 +1,328 @@
+package versionfile_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/gandarez/semver-action/internal/versionfile"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	tests := map[string]struct {
+		Target   versionfile.Target
+		Data     string
+		Expected string
+	}{
+		"package.json": {
+			Target: versionfile.Target{Type: versionfile.TypeJSON},
+			Data: `{
+  "name": "app",
+  "dependencies": {
+    "lib": {"version": "0.1.0"}
+  },
+  "scripts": ["a:b", "version"],
+  "version":   "1.2.3",
+  "private": true
+}
+`,
+			Expected: `{
+  "name": "app",
+  "dependencies": {
+    "lib": {"version": "0.1.0"}
+  },
+  "scripts": ["a:b", "version"],
+  "version":   "1.3.0",
+  "private": true
+}
+`,
+		},
+		"package.json with escaped quotes": {
+			Target:   versionfile.Target{Type: versionfile.TypeJSON},
+			Data:     "{\"description\": \"a \\\"quoted\\\" text\", \"version\": \"1.2.3-\\\"x\"}",
+			Expected: "{\"description\": \"a \\\"quoted\\\" text\", \"version\": \"1.3.0\"}",
+		},
+		"Cargo.toml": {
+			Target: versionfile.Target{Type: versionfile.TypeCargo},
+			Data: `[package]
+name = "app"
+edition = "2021"
+version = "1.2.3" # keep this comment
+
+[dependencies]
+serde = { version = "1.0" }
+
+[[bin]]
+name = "app"
+`,
+			Expected: `[package]
+name = "app"
+edition = "2021"
+version = "1.3.0" # keep this comment
+
+[dependencies]
+serde = { version = "1.0" }
+
+[[bin]]
+name = "app"
+`,
+		},
+		"Cargo.toml workspace": {
+			Target: versionfile.Target{Type: versionfile.TypeCargo},
+			Data: `[workspace]
+members = ["a", "b"]
+
+[workspace.dependencies]
+version = "0.0.1"
+
+[ workspace . package ]
+version = '1.2.3'
+`,
+			Expected: `[workspace]
+members = ["a", "b"]
+
+[workspace.dependencies]
+version = "0.0.1"
+
+[ workspace . package ]
+version = '1.3.0'
+`,
+		},
+		"pyproject.toml": {
+			Target: versionfile.Target{Type: versionfile.TypePyproject},
+			Data: `[build-system]
+requires = ["poetry-core"]
+
+[tool.poetry]
+name = "app"
+version = "1.2.3"
+`,
+			Expected: `[build-system]
+requires = ["poetry-core"]
+
+[tool.poetry]
+name = "app"
+version = "1.3.0"
+`,
+		},
+		"pom.xml": {
+			Target: versionfile.Target{Type: versionfile.TypePom},
+			Data: `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <parent>
+    <groupId>org.example</groupId>
+    <version>9.9.9</version>
+  </parent>
+  <!-- <version>0.0.0</version> -->
+  <artifactId>app</artifactId>
+  <version>1.2.3</version>
+  <dependencies>
+    <dependency>
+      <version>2.0.0</version>
+    </dependency>
+  </dependencies>
+</project>
+`,
+			Expected: `<?xml version="1.0" encoding="UTF-8"?>
+<project xmlns="http://maven.apache.org/POM/4.0.0">
+  <parent>
+    <groupId>org.example</groupId>
+    <version>9.9.9</version>
+  </parent>
+  <!-- <version>0.0.0</version> -->
+  <artifactId>app</artifactId>
+  <version>1.3.0</version>
+  <dependencies>
+    <dependency>
+      <version>2.0.0</version>
+    </dependency>
+  </dependencies>
+</project>
+`,
+		},
+		"Chart.yaml": {
+			Target: versionfile.Target{Type: versionfile.TypeChart},
+			Data: `apiVersion: v2
+name: app
+version: 1.2.3 # chart version
+appVersion: "1.2.3"
+dependencies:
+  - name: redis
+    version: 17.0.0
+`,
+			Expected: `apiVersion: v2
+name: app
+version: 1.3.0 # chart version
+appVersion: "1.3.0"
+dependencies:
+  - name: redis
+    version: 17.0.0
+`,
+		},
+		"VERSION": {
+			Target:   versionfile.Target{Type: versionfile.TypePlain},
+			Data:     "1.2.3\n",
+			Expected: "1.3.0\n",
+		},
+		"VERSION without trailing newline": {
+			Target:   versionfile.Target{Type: versionfile.TypePlain},
+			Data:     "1.2.3",
+			Expected: "1.3.0",
+		},
+		"regex": {
+			Target: versionfile.Target{
+				Type:    versionfile.TypeRegex,
+				Pattern: regexp.MustCompile(`Version = "([^"]+)"`),
+			},
+			Data:     "package app\n\nconst Version = \"1.2.3\"\n",
+			Expected: "package app\n\nconst Version = \"1.3.0\"\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			content, err := versionfile.Render(test.Target, []byte(test.Data), "1.3.0")
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, string(content))
+		})
+	}
+}
+
+func TestRender_Err(t *testing.T) {
+	tests := map[string]struct {
+		Target   versionfile.Target
+		Data     string
+		Expected string
+	}{
+		"json without version": {
+			Target:   versionfile.Target{Type: versionfile.TypeJSON},
+			Data:     `{"name": "app", "nested": {"version": "1.2.3"}}`,
+			Expected: "version not found",
+		},
+		"json version not a string": {
+			Target:   versionfile.Target{Type: versionfile.TypeJSON},
+			Data:     `{"version": 1}`,
+			Expected: "version must be a string",
+		},
+		"json array": {
+			Target:   versionfile.Target{Type: versionfile.TypeJSON},
+			Data:     `[]`,
+			Expected: "json must be an object",
+		},
+		"cargo workspace inherited version": {
+			Target:   versionfile.Target{Type: versionfile.TypeCargo},
+			Data:     "[package]\nversion.workspace = true\n",
+			Expected: "version not found",
+		},
+		"pom with parent version only": {
+			Target:   versionfile.Target{Type: versionfile.TypePom},
+			Data:     "<project><parent><version>1.0.0</version></parent></project>",
+			Expected: "version not found",
+		},
+		"chart without version": {
+			Target:   versionfile.Target{Type: versionfile.TypeChart},
+			Data:     "name: app\n",
+			Expected: "version not found",
+		},
+		"regex without capture group": {
+			Target: versionfile.Target{
+				Type:    versionfile.TypeRegex,
+				Pattern: regexp.MustCompile(`Version`),
+			},
+			Data:     "Version",
+			Expected: "pattern must have one capture group",
+		},
+		"regex without match": {
+			Target: versionfile.Target{
+				Type:    versionfile.TypeRegex,
+				Pattern: regexp.MustCompile(`Version = "(.+)"`),
+			},
+			Data:     "package app",
+			Expected: "version not found",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := versionfile.Render(test.Target, []byte(test.Data), "1.3.0")
+			require.Error(t, err)
+
+			assert.Equal(t, test.Expected, err.Error())
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "VERSION"), []byte("1.3.0\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "package.json"), []byte(`{"version": "1.2.3"}`), 0644))
+
+	changed, err := versionfile.Write(dir, []versionfile.Target{
+		{Path: "VERSION", Type: versionfile.TypePlain, Version: "1.3.0"},
+		{Path: "package.json", Type: versionfile.TypeJSON, Version: "1.3.0"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"package.json"}, changed)
+
+	data, err := os.ReadFile(filepath.Join(dir, "package.json"))
+	require.NoError(t, err)
+
+	assert.Equal(t, `{"version": "1.3.0"}`, string(data))
+
+	info, err := os.Stat(filepath.Join(dir, "package.json"))
+	require.NoError(t, err)
+
+	assert.Equal(t, os.FileMode(0644), info.Mode().Perm())
+}
+
+func TestWrite_NothingWrittenOnError(t *testing.T) {
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "VERSION"), []byte("1.2.3\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "Chart.yaml"), []byte("name: app\n"), 0600))
+
+	_, err := versionfile.Write(dir, []versionfile.Target{
+		{Path: "VERSION", Type: versionfile.TypePlain, Version: "1.3.0"},
+		{Path: "Chart.yaml", Type: versionfile.TypeChart, Version: "1.3.0"},
+	})
+	require.Error(t, err)
+
+	assert.Equal(t, "failed to write version into Chart.yaml: version not found", err.Error())
+
+	data, err := os.ReadFile(filepath.Join(dir, "VERSION"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "1.2.3\n", string(data))
+}
+
+func TestDetect(t *testing.T) {
+	tests := map[string]struct {
+		Path     string
+		Expected versionfile.Type
+		Found    bool
+	}{
+		"package.json": {Path: "web/package.json", Expected: versionfile.TypeJSON, Found: true},
+		"Cargo.toml":   {Path: "Cargo.toml", Expected: versionfile.TypeCargo, Found: true},
+		"pyproject":    {Path: "pyproject.toml", Expected: versionfile.TypePyproject, Found: true},
+		"pom.xml":      {Path: "pom.xml", Expected: versionfile.TypePom, Found: true},
+		"Chart.yaml":   {Path: "charts/app/Chart.yaml", Expected: versionfile.TypeChart, Found: true},
+		"VERSION":      {Path: "VERSION", Expected: versionfile.TypePlain, Found: true},
+		"unknown":      {Path: "version.go"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			typ, ok := versionfile.Detect(test.Path)
+
+			assert.Equal(t, test.Found, ok)
+			assert.Equal(t, test.Expected, typ)
+		})
+	}
+}
@@ -1,18 +1,26 @@
 package main
 
 import (
+	"encoding/json"
 	"os"
 	"strconv"
 
+	"github.com/gandarez/semver-action/cmd/cli"
 	"github.com/gandarez/semver-action/cmd/generate"
 	"github.com/gandarez/semver-action/pkg/actions"
 
 	"github.com/apex/log"
-	"github.com/apex/log/handlers/cli"
+	logcli "github.com/apex/log/handlers/cli"
 )
 
 func main() {
-	log.SetHandler(cli.Default)
+	log.SetHandler(logcli.Default)
+
+	// Docker actions receive the inputs as args, so subcommands are only
+	// handled when the first arg is a known command.
+	if len(os.Args) > 1 && cli.IsCommand(os.Args[1]) {
+		os.Exit(cli.Run(os.Args[1:], os.Stdout, os.Stderr))
+	}
 
 	result, err := generate.Run()
 	if err != nil {
@@ -21,6 +29,15 @@ func main() {
 
 	outputFilepath := os.Getenv("GITHUB_OUTPUT")
 
+	// Not running inside Github Actions.
+	if outputFilepath == "" {
+		if err := cli.PrintText(os.Stdout, result); err != nil {
+			log.Fatalf("%s\n", err)
+		}
+
+		return
+	}
+
 	// Print previous tag.
 	log.Infof("PREVIOUS_TAG: %s", result.PreviousTag)
 
@@ -48,4 +65,116 @@ func main() {
 	if err := actions.SetOutput(outputFilepath, "IS_PRERELEASE", strconv.FormatBool(result.IsPrerelease)); err != nil {
 		log.Fatalf("%s\n", err)
 	}
+
+	// Print version and its parts.
+	parts := [][2]string{
+		{"VERSION", result.Version},
+		{"MAJOR", strconv.FormatUint(result.Major, 10)},
+		{"MINOR", strconv.FormatUint(result.Minor, 10)},
+		{"PATCH", strconv.FormatUint(result.Patch, 10)},
+		{"PRERELEASE", result.Prerelease},
+		{"BUILD", result.Build},
+		{"BUMP", result.Bump},
+		{"IS_MAJOR", strconv.FormatBool(result.IsMajor)},
+		{"IS_MINOR", strconv.FormatBool(result.IsMinor)},
+		{"IS_PATCH", strconv.FormatBool(result.IsPatch)},
+	}
+
+	for _, part := range parts {
+		log.Infof("%s: %s", part[0], part[1])
+
+		if err := actions.SetOutput(outputFilepath, part[0], part[1]); err != nil {
+			log.Fatalf("%s\n", err)
+		}
+	}
+
+	// Print docker tags.
+	if result.DockerTags != "" {
+		log.Infof("DOCKER_TAGS:\n%s", result.DockerTags)
+	}
+
+	if err := actions.SetOutput(outputFilepath, "DOCKER_TAGS", result.DockerTags); err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+	// Print changelog.
+	if result.Changelog != "" {
+		log.Infof("CHANGELOG:\n%s", result.Changelog)
+	}
+
+	if err := actions.SetOutput(outputFilepath, "CHANGELOG", result.Changelog); err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+	// Print tag created.
+	log.Infof("TAG_CREATED: %v", result.TagCreated)
+
+	if err := actions.SetOutput(outputFilepath, "TAG_CREATED", strconv.FormatBool(result.TagCreated)); err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+	// Print tag pushed.
+	log.Infof("TAG_PUSHED: %v", result.TagPushed)
+
+	if err := actions.SetOutput(outputFilepath, "TAG_PUSHED", strconv.FormatBool(result.TagPushed)); err != nil {
+		log.Fatalf("%s\n", err)
+	}
+
+	if result.Trace != nil {
+		explain, err := json.Marshal(result.Trace)
+		if err != nil {
+			log.Fatalf("failed to marshal explain: %s\n", err)
+		}
+
+		// Print explain.
+		log.Debugf("EXPLAIN: %s", explain)
+
+		if err := actions.SetOutput(outputFilepath, "EXPLAIN", string(explain)); err != nil {
+			log.Fatalf("%s\n", err)
+		}
+	}
+
+	if result.VersionFiles != nil {
+		versionFiles, err := json.Marshal(result.VersionFiles)
+		if err != nil {
+			log.Fatalf("failed to marshal version files: %s\n", err)
+		}
+
+		// Print version files.
+		log.Infof("VERSION_FILES: %s", versionFiles)
+
+		if err := actions.SetOutput(outputFilepath, "VERSION_FILES", string(versionFiles)); err != nil {
+			log.Fatalf("%s\n", err)
+		}
+	}
+
+	if result.SkippedTags != nil {
+		skippedTags, err := json.Marshal(result.SkippedTags)
+		if err != nil {
+			log.Fatalf("failed to marshal skipped tags: %s\n", err)
+		}
+
+		// Print skipped tags.
+		log.Infof("SKIPPED_TAGS: %s", skippedTags)
+
+		if err := actions.SetOutput(outputFilepath, "SKIPPED_TAGS", string(skippedTags)); err != nil {
+			log.Fatalf("%s\n", err)
+		}
+	}
+
+	if result.Components == nil {
+		return
+	}
+
+	components, err := json.Marshal(result.Components)
+	if err != nil {
+		log.Fatalf("failed to marshal components: %s\n", err)
+	}
+
+	// Print components.
+	log.Infof("COMPONENTS: %s", components)
+
+	if err := actions.SetOutput(outputFilepath, "COMPONENTS", string(components)); err != nil {
+		log.Fatalf("%s\n", err)
+	}
 }
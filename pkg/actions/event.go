@@ -0,0 +1,100 @@
+package actions
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type (
+	// Event contains the subset of the workflow event payload used by semver.
+	Event struct {
+		// Name is the name of the event that triggered the workflow, e.g. push.
+		Name        string       `json:"-"`
+		Ref         string       `json:"ref"`
+		PullRequest *PullRequest `json:"pull_request"`
+	}
+
+	// PullRequest contains the pull request of pull_request events.
+	PullRequest struct {
+		Number int     `json:"number"`
+		Merged bool    `json:"merged"`
+		Head   Branch  `json:"head"`
+		Base   Branch  `json:"base"`
+		Labels []Label `json:"labels"`
+	}
+
+	// Label contains a pull request label.
+	Label struct {
+		Name string `json:"name"`
+	}
+
+	// Branch contains a pull request head or base branch.
+	Branch struct {
+		Ref string `json:"ref"`
+	}
+)
+
+// LoadEvent reads the event payload from the given file path.
+func LoadEvent(name, fp string) (*Event, error) {
+	data, err := os.ReadFile(fp) // nolint:gosec
+	if err != nil {
+		return nil, fmt.Errorf("failed to read event payload: %s", err)
+	}
+
+	event := Event{Name: name}
+
+	if err := json.Unmarshal(data, &event); err != nil {
+		return nil, fmt.Errorf("failed to parse event payload: %s", err)
+	}
+
+	return &event, nil
+}
+
+// IsPullRequest returns true if the event was triggered by a pull request.
+func (e *Event) IsPullRequest() bool {
+	return (e.Name == "pull_request" || e.Name == "pull_request_target") && e.PullRequest != nil
+}
+
+// Labels returns the label names of the pull request, or nil if the event was
+// not triggered by a pull request.
+func (e *Event) Labels() []string {
+	if !e.IsPullRequest() {
+		return nil
+	}
+
+	var names []string
+
+	for _, label := range e.PullRequest.Labels {
+		names = append(names, label.Name)
+	}
+
+	return names
+}
+
+// Branch returns the branch name of the pushed ref, or empty if the ref is not a branch.
+func (e *Event) Branch() string {
+	if !strings.HasPrefix(e.Ref, "refs/heads/") {
+		return ""
+	}
+
+	return strings.TrimPrefix(e.Ref, "refs/heads/")
+}
+
+// PullRequestNumber returns the pull request number of refs/pull/<number>/merge
+// and refs/pull/<number>/head refs, or zero for any other ref.
+func PullRequestNumber(ref string) int {
+	parts := strings.Split(ref, "/")
+	if len(parts) != 4 || parts[0] != "refs" || parts[1] != "pull" || (parts[3] != "merge" && parts[3] != "head") {
+		return 0
+	}
+
+	number, err := strconv.Atoi(parts[2])
+	if err != nil || number < 1 {
+		return 0
+	}
+
+	return number
+}
@@ -0,0 +1,120 @@
+package actions_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gandarez/semver-action/pkg/actions"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadEvent_PullRequest(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "event.json")
+
+	err := os.WriteFile(fp, []byte(`{
+		"action": "closed",
+		"number": 12,
+		"pull_request": {
+			"number": 12,
+			"merged": true,
+			"head": {"ref": "feature/some", "sha": "81918ffc"},
+			"base": {"ref": "develop", "sha": "e63c125b"},
+			"labels": [{"id": 1, "name": "semver:minor"}, {"id": 2, "name": "docs"}]
+		}
+	}`), 0600)
+	require.NoError(t, err)
+
+	event, err := actions.LoadEvent("pull_request", fp)
+	require.NoError(t, err)
+
+	assert.Equal(t, &actions.Event{
+		Name: "pull_request",
+		PullRequest: &actions.PullRequest{
+			Number: 12,
+			Merged: true,
+			Head:   actions.Branch{Ref: "feature/some"},
+			Base:   actions.Branch{Ref: "develop"},
+			Labels: []actions.Label{{Name: "semver:minor"}, {Name: "docs"}},
+		},
+	}, event)
+	assert.True(t, event.IsPullRequest())
+	assert.Equal(t, []string{"semver:minor", "docs"}, event.Labels())
+	assert.Empty(t, event.Branch())
+}
+
+func TestLoadEvent_Push(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "event.json")
+
+	err := os.WriteFile(fp, []byte(`{"ref": "refs/heads/master", "before": "e63c125b", "after": "81918ffc"}`), 0600)
+	require.NoError(t, err)
+
+	event, err := actions.LoadEvent("push", fp)
+	require.NoError(t, err)
+
+	assert.False(t, event.IsPullRequest())
+	assert.Nil(t, event.Labels())
+	assert.Equal(t, "master", event.Branch())
+}
+
+func TestLoadEvent_PushTag(t *testing.T) {
+	event := actions.Event{Name: "push", Ref: "refs/tags/v1.2.3"}
+
+	assert.Empty(t, event.Branch())
+}
+
+func TestLoadEvent_NotFound(t *testing.T) {
+	_, err := actions.LoadEvent("push", filepath.Join(t.TempDir(), "missing.json"))
+
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "failed to read event payload")
+}
+
+func TestLoadEvent_Invalid(t *testing.T) {
+	fp := filepath.Join(t.TempDir(), "event.json")
+
+	require.NoError(t, os.WriteFile(fp, []byte(`{`), 0600))
+
+	_, err := actions.LoadEvent("push", fp)
+
+	require.Error(t, err)
+
+	assert.Contains(t, err.Error(), "failed to parse event payload")
+}
+
+func TestPullRequestNumber(t *testing.T) {
+	tests := map[string]struct {
+		Ref      string
+		Expected int
+	}{
+		"merge ref": {
+			Ref:      "refs/pull/12/merge",
+			Expected: 12,
+		},
+		"head ref": {
+			Ref:      "refs/pull/7/head",
+			Expected: 7,
+		},
+		"branch": {
+			Ref:      "refs/heads/master",
+			Expected: 0,
+		},
+		"invalid number": {
+			Ref:      "refs/pull/abc/merge",
+			Expected: 0,
+		},
+		"empty": {
+			Ref:      "",
+			Expected: 0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, actions.PullRequestNumber(test.Ref))
+		})
+	}
+}
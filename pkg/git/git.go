@@ -6,13 +6,15 @@ import (
 	"fmt"
 	"os/exec"
 	"path/filepath"
-	"regexp"
 	"strings"
 
 	"github.com/apex/log"
 )
 
-var mergePRRegex = regexp.MustCompile(`Merge pull request #([0-9])+ from (?P<source>.*)+`) // nolint
+const (
+	botName  = "github-actions[bot]"
+	botEmail = "41898282+github-actions[bot]@users.noreply.github.com"
+)
 
 type (
 	// Git is an interface to git.
@@ -23,12 +25,37 @@ type (
 		LatestTag(include, exclude string) string
 		AncestorTag(include, exclude, branch string) string
 		SourceBranch(commitHash string) (string, error)
+		Trailers(commitHash string) ([]Trailer, error)
+		Commits(from, to string) ([]Commit, error)
+		MainlineCommits(from, to string) ([]Commit, error)
+		ChangedFiles(from, to string) ([]string, error)
+		Tags(pattern string) ([]string, error)
+		TagExists(name string) bool
+		CreateTag(name, commitHash, message string) error
+		PushTag(remote, name string) error
+		AddWorktree(dir, commitish string) error
+		RemoveWorktree(dir string) error
+	}
+
+	// Commit contains a commit hash and its full message.
+	Commit struct {
+		Hash    string
+		Message string
+	}
+
+	// Trailer is a key and value trailer of a commit message, e.g. Release-As: 2.0.0.
+	Trailer struct {
+		Key   string
+		Value string
 	}
 
 	// Client is a git client.
 	Client struct {
 		repoDir string
 		GitCmd  func(env map[string]string, args ...string) (string, error)
+		// MergeFormat is the merge message format used to extract the source
+		// branch. Empty or "auto" detects it. See MergeFormats().
+		MergeFormat string
 	}
 )
 
@@ -119,31 +146,50 @@ func (c Client) CurrentBranch() (string, error) {
 
 // SourceBranch tries to get branch from commit message.
 func (c Client) SourceBranch(commitHash string) (string, error) {
-	message, err := c.Clean(c.run("-C", c.repoDir, "log", "-1", "--pretty=%B", commitHash))
+	message, err := c.run("-C", c.repoDir, "log", "-1", "--pretty=%B", commitHash)
 	if err != nil {
-		return "", fmt.Errorf("could not get message from commit: %s", err)
+		return "", fmt.Errorf("could not get message from commit: %s", strings.TrimSuffix(err.Error(), "\n"))
 	}
 
-	match := mergePRRegex.FindStringSubmatch(message)
+	parsed, err := ParseMergeMessage(c.MergeFormat, message)
+	if err != nil {
+		return "", err
+	}
 
-	paramsMap := make(map[string]string)
-	for i, name := range mergePRRegex.SubexpNames() {
-		if i > 0 && i <= len(match) {
-			paramsMap[name] = match[i]
-		}
+	if parsed.SourceBranch == "" {
+		return "", ErrNoSourceBranch
 	}
 
-	if len(paramsMap) == 0 || paramsMap["source"] == "" {
-		return "", errors.New("no source branch found")
+	return parsed.SourceBranch, nil
+}
+
+// Trailers returns the trailers of the commit message in order. Empty commitHash
+// means HEAD.
+func (c Client) Trailers(commitHash string) ([]Trailer, error) {
+	if commitHash == "" {
+		commitHash = "HEAD"
 	}
 
-	splitted := strings.SplitN(paramsMap["source"], "/", 2)
+	out, err := c.run("-C", c.repoDir, "log", "-1", "--format=%(trailers:only,unfold)", commitHash)
+	if err != nil {
+		return nil, fmt.Errorf("could not get trailers from commit: %s", strings.TrimSpace(err.Error()))
+	}
 
-	if len(splitted) < 2 {
-		return "", fmt.Errorf("commit message does not contain expected format: %s", paramsMap["source"])
+	var trailers []Trailer
+
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+
+		trailers = append(trailers, Trailer{
+			Key:   strings.TrimSpace(key),
+			Value: strings.TrimSpace(value),
+		})
 	}
 
-	return splitted[1], nil
+	return trailers, nil
 }
 
 // LatestTag returns the latest tag matching include and not matching exclude, if found.
@@ -185,6 +231,171 @@ func (c Client) AncestorTag(include, exclude, branch string) string {
 	return result
 }
 
+// Commits returns the commits reachable from to and not reachable from from, newest first.
+// Pass empty from to list the whole history reachable from to. Empty to means HEAD.
+func (c Client) Commits(from, to string) ([]Commit, error) {
+	return c.log(from, to)
+}
+
+// MainlineCommits is like Commits but only follows the first parent of merge
+// commits, so each merged branch is listed once by its merge commit.
+func (c Client) MainlineCommits(from, to string) ([]Commit, error) {
+	return c.log(from, to, "--first-parent")
+}
+
+func (c Client) log(from, to string, extraArgs ...string) ([]Commit, error) {
+	if to == "" {
+		to = "HEAD"
+	}
+
+	revision := to
+	if from != "" {
+		revision = from + ".." + to
+	}
+
+	args := append([]string{"-C", c.repoDir, "log", "--format=%H%x1f%B%x1e"}, extraArgs...)
+
+	out, err := c.run(append(args, revision)...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get commits for %s: %s", revision, strings.TrimSpace(err.Error()))
+	}
+
+	var commits []Commit
+
+	for _, record := range strings.Split(out, "\x1e") {
+		record = strings.TrimSpace(record)
+		if record == "" {
+			continue
+		}
+
+		hash, message, _ := strings.Cut(record, "\x1f")
+
+		commits = append(commits, Commit{
+			Hash:    strings.TrimSpace(hash),
+			Message: strings.TrimSpace(message),
+		})
+	}
+
+	return commits, nil
+}
+
+// ChangedFiles returns the paths of the files changed between from and to.
+// Pass empty from to list every file tracked at to. Empty to means HEAD.
+func (c Client) ChangedFiles(from, to string) ([]string, error) {
+	if to == "" {
+		to = "HEAD"
+	}
+
+	args := []string{"-C", c.repoDir, "diff", "--name-only", from, to}
+	if from == "" {
+		args = []string{"-C", c.repoDir, "ls-tree", "-r", "--name-only", to}
+	}
+
+	out, err := c.run(args...)
+	if err != nil {
+		return nil, fmt.Errorf("could not get changed files: %s", strings.TrimSpace(err.Error()))
+	}
+
+	var files []string
+
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			files = append(files, line)
+		}
+	}
+
+	return files, nil
+}
+
+// Tags returns every local tag matching the git glob pattern, reachable or not.
+func (c Client) Tags(pattern string) ([]string, error) {
+	out, err := c.run("-C", c.repoDir, "tag", "--list", pattern)
+	if err != nil {
+		return nil, fmt.Errorf("could not list tags: %s", strings.TrimSpace(err.Error()))
+	}
+
+	var tags []string
+
+	for _, line := range strings.Split(out, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			tags = append(tags, line)
+		}
+	}
+
+	return tags, nil
+}
+
+// TagExists returns true if the tag already exists locally.
+func (c Client) TagExists(name string) bool {
+	_, err := c.run("-C", c.repoDir, "rev-parse", "--quiet", "--verify", "refs/tags/"+name)
+	return err == nil
+}
+
+// CreateTag creates a tag pointing at commitHash. An annotated tag is created
+// when message is not empty, otherwise a lightweight one. It refuses to
+// overwrite an existing tag. When no git identity is configured, the annotated
+// tag is created by the GitHub Actions bot.
+func (c Client) CreateTag(name, commitHash, message string) error {
+	if commitHash == "" {
+		commitHash = "HEAD"
+	}
+
+	if c.TagExists(name) {
+		return fmt.Errorf("tag %s already exists", name)
+	}
+
+	args := []string{"-C", c.repoDir}
+
+	if message != "" {
+		if email, _ := c.Clean(c.run("-C", c.repoDir, "config", "user.email")); email == "" {
+			args = append(args, "-c", "user.name="+botName, "-c", "user.email="+botEmail)
+		}
+
+		args = append(args, "tag", "--annotate", "--message", message, name, commitHash)
+	} else {
+		args = append(args, "tag", name, commitHash)
+	}
+
+	if _, err := c.run(args...); err != nil {
+		return fmt.Errorf("could not create tag %s: %s", name, strings.TrimSpace(err.Error()))
+	}
+
+	return nil
+}
+
+// PushTag pushes the tag to the remote. It never forces, so an existing
+// tag in the remote is not overwritten.
+func (c Client) PushTag(remote, name string) error {
+	if _, err := c.run("-C", c.repoDir, "push", remote, "refs/tags/"+name); err != nil {
+		return fmt.Errorf("could not push tag %s to %s: %s", name, remote, strings.TrimSpace(err.Error()))
+	}
+
+	return nil
+}
+
+// AddWorktree checks out commitish into a new detached worktree at dir.
+// Empty commitish means HEAD.
+func (c Client) AddWorktree(dir, commitish string) error {
+	if commitish == "" {
+		commitish = "HEAD"
+	}
+
+	if _, err := c.run("-C", c.repoDir, "worktree", "add", "--detach", dir, commitish); err != nil {
+		return fmt.Errorf("could not add worktree of %s: %s", commitish, strings.TrimSpace(err.Error()))
+	}
+
+	return nil
+}
+
+// RemoveWorktree removes the worktree at dir, discarding its changes.
+func (c Client) RemoveWorktree(dir string) error {
+	if _, err := c.run("-C", c.repoDir, "worktree", "remove", "--force", dir); err != nil {
+		return fmt.Errorf("could not remove worktree %s: %s", dir, strings.TrimSpace(err.Error()))
+	}
+
+	return nil
+}
+
 // run runs a git command and returns its output or errors.
 func (c Client) run(args ...string) (string, error) {
 	return c.GitCmd(nil, args...)
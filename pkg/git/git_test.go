@@ -171,6 +171,78 @@ func TestSourceBranch_NotValiddBranchName(t *testing.T) {
 	assert.EqualError(t, err, "commit message does not contain expected format: semver-initial")
 }
 
+func TestSourceBranch_MergeFormat(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.MergeFormat = "gitlab"
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{"-C", "/path/to/repo", "log", "-1", "--pretty=%B", "81918ffc"})
+
+		return "Merge branch 'feature/semver-initial' into 'develop'\n\nSee merge request group/project!12\n", nil
+	}
+
+	value, err := gc.SourceBranch("81918ffc")
+	require.NoError(t, err)
+
+	assert.Equal(t, "feature/semver-initial", value)
+}
+
+func TestSourceBranch_MergeFormatMismatch(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.MergeFormat = "github"
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		return "Merge branch 'feature/semver-initial' into 'develop'", nil
+	}
+
+	_, err := gc.SourceBranch("81918ffc")
+
+	assert.EqualError(t, err, "no source branch found")
+}
+
+func TestTrailers(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{"-C", "/path/to/repo", "log", "-1", "--format=%(trailers:only,unfold)", "81918ffc"})
+
+		return "Release-As: 2.0.0\nSigned-off-by: John Doe <john@example.com>\n\n", nil
+	}
+
+	trailers, err := gc.Trailers("81918ffc")
+	require.NoError(t, err)
+
+	assert.Equal(t, []git.Trailer{
+		{Key: "Release-As", Value: "2.0.0"},
+		{Key: "Signed-off-by", Value: "John Doe <john@example.com>"},
+	}, trailers)
+}
+
+func TestTrailers_NoTrailers(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Equal(t, args, []string{"-C", "/path/to/repo", "log", "-1", "--format=%(trailers:only,unfold)", "HEAD"})
+
+		return "\n", nil
+	}
+
+	trailers, err := gc.Trailers("")
+	require.NoError(t, err)
+
+	assert.Empty(t, trailers)
+}
+
+func TestTrailersErr(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		return "", errors.New("fatal: bad object 81918ffc\n")
+	}
+
+	_, err := gc.Trailers("81918ffc")
+	require.Error(t, err)
+
+	assert.EqualError(t, err, "could not get trailers from commit: fatal: bad object 81918ffc")
+}
+
 func TestLatestTag(t *testing.T) {
 	gc := git.New("/path/to/repo")
 	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
@@ -339,3 +411,288 @@ func TestAncestorTag_NoTagFound(t *testing.T) {
 
 	assert.Empty(t, value)
 }
+
+func TestCommits(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{"-C", "/path/to/repo", "log", "--format=%H%x1f%B%x1e", "v1.2.3..81918ffc"})
+
+		return "81918ffc\x1ffeat: new api\n\nBREAKING CHANGE: removed old api\n\x1e\n" +
+			"e63c125b\x1ffix: some bug\n\x1e\n", nil
+	}
+
+	commits, err := gc.Commits("v1.2.3", "81918ffc")
+	require.NoError(t, err)
+
+	assert.Equal(t, []git.Commit{
+		{Hash: "81918ffc", Message: "feat: new api\n\nBREAKING CHANGE: removed old api"},
+		{Hash: "e63c125b", Message: "fix: some bug"},
+	}, commits)
+}
+
+func TestCommits_NoLatestTag(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{"-C", "/path/to/repo", "log", "--format=%H%x1f%B%x1e", "HEAD"})
+
+		return "", nil
+	}
+
+	commits, err := gc.Commits("", "")
+	require.NoError(t, err)
+
+	assert.Empty(t, commits)
+}
+
+func TestMainlineCommits(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{
+			"-C", "/path/to/repo", "log", "--format=%H%x1f%B%x1e", "--first-parent", "v1.2.3..81918ffc"})
+
+		return "81918ffc\x1fMerge pull request #12 from gandarez/feature/some\n\nAdd some\n\x1e\n", nil
+	}
+
+	commits, err := gc.MainlineCommits("v1.2.3", "81918ffc")
+	require.NoError(t, err)
+
+	assert.Equal(t, []git.Commit{
+		{Hash: "81918ffc", Message: "Merge pull request #12 from gandarez/feature/some\n\nAdd some"},
+	}, commits)
+}
+
+func TestCommitsErr(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		return "", errors.New("error\n")
+	}
+
+	_, err := gc.Commits("v1.2.3", "HEAD")
+
+	assert.EqualError(t, err, "could not get commits for v1.2.3..HEAD: error")
+}
+
+func TestChangedFiles(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{"-C", "/path/to/repo", "diff", "--name-only", "api/v1.2.3", "81918ffc"})
+
+		return "services/api/main.go\nREADME.md\n", nil
+	}
+
+	files, err := gc.ChangedFiles("api/v1.2.3", "81918ffc")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"services/api/main.go", "README.md"}, files)
+}
+
+func TestChangedFiles_NoLatestTag(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{"-C", "/path/to/repo", "ls-tree", "-r", "--name-only", "HEAD"})
+
+		return "go.mod\nmain.go\n", nil
+	}
+
+	files, err := gc.ChangedFiles("", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"go.mod", "main.go"}, files)
+}
+
+func TestChangedFilesErr(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		return "", errors.New("error\n")
+	}
+
+	_, err := gc.ChangedFiles("v1.2.3", "HEAD")
+
+	assert.EqualError(t, err, "could not get changed files: error")
+}
+
+func TestTags(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{"-C", "/path/to/repo", "tag", "--list", "v1.3.0-pr.12.*"})
+
+		return "v1.3.0-pr.12.1\nv1.3.0-pr.12.2\n", nil
+	}
+
+	tags, err := gc.Tags("v1.3.0-pr.12.*")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"v1.3.0-pr.12.1", "v1.3.0-pr.12.2"}, tags)
+}
+
+func TestTagsErr(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		return "", errors.New("error\n")
+	}
+
+	_, err := gc.Tags("v*")
+
+	assert.EqualError(t, err, "could not list tags: error")
+}
+
+func TestTagExists(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{"-C", "/path/to/repo", "rev-parse", "--quiet", "--verify", "refs/tags/v1.2.3"})
+
+		return "81918ffc", nil
+	}
+
+	assert.True(t, gc.TagExists("v1.2.3"))
+}
+
+func TestTagExists_NotFound(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		return "", errors.New("")
+	}
+
+	assert.False(t, gc.TagExists("v1.2.3"))
+}
+
+func TestCreateTag_Lightweight(t *testing.T) {
+	var numCalls int
+
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		numCalls++
+
+		assert.Nil(t, env)
+
+		switch numCalls {
+		case 1:
+			assert.Equal(t, args, []string{"-C", "/path/to/repo", "rev-parse", "--quiet", "--verify", "refs/tags/v1.2.3"})
+			return "", errors.New("")
+		case 2:
+			assert.Equal(t, args, []string{"-C", "/path/to/repo", "tag", "v1.2.3", "81918ffc"})
+		}
+
+		return "", nil
+	}
+
+	err := gc.CreateTag("v1.2.3", "81918ffc", "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, numCalls)
+}
+
+func TestCreateTag_AnnotatedWithoutIdentity(t *testing.T) {
+	var numCalls int
+
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		numCalls++
+
+		assert.Nil(t, env)
+
+		switch numCalls {
+		case 1:
+			return "", errors.New("")
+		case 2:
+			assert.Equal(t, args, []string{"-C", "/path/to/repo", "config", "user.email"})
+			return "", errors.New("")
+		case 3:
+			assert.Equal(t, args, []string{
+				"-C", "/path/to/repo",
+				"-c", "user.name=github-actions[bot]",
+				"-c", "user.email=41898282+github-actions[bot]@users.noreply.github.com",
+				"tag", "--annotate", "--message", "Release v1.2.3", "v1.2.3", "HEAD",
+			})
+		}
+
+		return "", nil
+	}
+
+	err := gc.CreateTag("v1.2.3", "", "Release v1.2.3")
+	require.NoError(t, err)
+
+	assert.Equal(t, 3, numCalls)
+}
+
+func TestCreateTag_AlreadyExists(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		return "81918ffc", nil
+	}
+
+	err := gc.CreateTag("v1.2.3", "81918ffc", "")
+
+	assert.EqualError(t, err, "tag v1.2.3 already exists")
+}
+
+func TestPushTag(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{"-C", "/path/to/repo", "push", "origin", "refs/tags/v1.2.3"})
+
+		return "", nil
+	}
+
+	err := gc.PushTag("origin", "v1.2.3")
+	require.NoError(t, err)
+}
+
+func TestPushTagErr(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		return "", errors.New("! [rejected] v1.2.3 -> v1.2.3 (already exists)\n")
+	}
+
+	err := gc.PushTag("origin", "v1.2.3")
+
+	assert.EqualError(t, err, "could not push tag v1.2.3 to origin: ! [rejected] v1.2.3 -> v1.2.3 (already exists)")
+}
+
+func TestAddWorktree(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{"-C", "/path/to/repo", "worktree", "add", "--detach", "/tmp/previous", "v1.2.3"})
+
+		return "", nil
+	}
+
+	err := gc.AddWorktree("/tmp/previous", "v1.2.3")
+	require.NoError(t, err)
+}
+
+func TestAddWorktreeErr(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Equal(t, args, []string{"-C", "/path/to/repo", "worktree", "add", "--detach", "/tmp/current", "HEAD"})
+
+		return "", errors.New("fatal: '/tmp/current' already exists\n")
+	}
+
+	err := gc.AddWorktree("/tmp/current", "")
+	require.Error(t, err)
+
+	assert.EqualError(t, err, "could not add worktree of HEAD: fatal: '/tmp/current' already exists")
+}
+
+func TestRemoveWorktree(t *testing.T) {
+	gc := git.New("/path/to/repo")
+	gc.GitCmd = func(env map[string]string, args ...string) (string, error) {
+		assert.Nil(t, env)
+		assert.Equal(t, args, []string{"-C", "/path/to/repo", "worktree", "remove", "--force", "/tmp/previous"})
+
+		return "", nil
+	}
+
+	err := gc.RemoveWorktree("/tmp/previous")
+	require.NoError(t, err)
+}
@@ -0,0 +1,184 @@
+package git
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrNoSourceBranch is returned when a merge commit message does not contain a source branch.
+var ErrNoSourceBranch = errors.New("no source branch found")
+
+// nolint: gochecknoglobals
+var (
+	githubMergeRegex    = regexp.MustCompile(`^Merge pull request #(?P<number>[0-9]+) from (?P<source>\S+)`)
+	gitlabMergeRegex    = regexp.MustCompile(`^Merge branch '(?P<source>[^']+)' into '(?P<dest>[^']+)'`)
+	gitlabRequestRegex  = regexp.MustCompile(`(?m)^See merge request \S*!(?P<number>[0-9]+)`)
+	bitbucketMergeRegex = regexp.MustCompile(`^Merged in (?P<source>\S+) \(pull request #(?P<number>[0-9]+)\)`)
+	azureMergeRegex     = regexp.MustCompile(`^Merged PR (?P<number>[0-9]+): (?P<title>.*)`)
+	azureTitleRegex     = regexp.MustCompile(`^Merge(?:d)? (?:branch )?'?(?P<source>[^' ]+)'? (?:in)?to `)
+	gitMergeRegex       = regexp.MustCompile(`^Merge (?P<remote>remote-tracking )?branch '(?P<source>[^']+)'`)
+
+	mergeParsers = map[string]MergeParser{
+		"github":    parseGitHubMerge,
+		"gitlab":    parseGitLabMerge,
+		"bitbucket": parseBitbucketMerge,
+		"azure":     parseAzureMerge,
+		"git":       parseGitMerge,
+	}
+	// mergeFormats is the order formats are tried when auto detecting.
+	mergeFormats = []string{"github", "gitlab", "bitbucket", "azure", "git"}
+)
+
+type (
+	// MergeMessage contains the information parsed from a merge commit message.
+	MergeMessage struct {
+		SourceBranch string
+		// PRNumber is the pull request number. It's zero when the format does not include it.
+		PRNumber int
+	}
+
+	// MergeParser parses a merge commit message of a specific host format.
+	// It returns ErrNoSourceBranch if the message is not in the expected format.
+	MergeParser func(message string) (MergeMessage, error)
+)
+
+// MergeFormats returns the supported merge message formats, including "auto".
+func MergeFormats() []string {
+	return append([]string{"auto"}, mergeFormats...)
+}
+
+// ParseMergeMessage parses a merge commit message using the parser of the given
+// format. Format "auto", or empty, tries every supported format in order. When
+// no format finds the source branch, the pull request number found by any of
+// them is returned along with ErrNoSourceBranch.
+func ParseMergeMessage(format, message string) (MergeMessage, error) {
+	message = strings.TrimSpace(message)
+
+	if format != "" && format != "auto" {
+		parser, ok := mergeParsers[format]
+		if !ok {
+			return MergeMessage{}, fmt.Errorf("unsupported merge message format: %s", format)
+		}
+
+		return parser(message)
+	}
+
+	var partial MergeMessage
+
+	for _, name := range mergeFormats {
+		parsed, err := mergeParsers[name](message)
+		if errors.Is(err, ErrNoSourceBranch) {
+			if partial.PRNumber == 0 {
+				partial = parsed
+			}
+
+			continue
+		}
+
+		return parsed, err
+	}
+
+	return partial, ErrNoSourceBranch
+}
+
+// parseGitHubMerge parses `Merge pull request #N from owner/branch`.
+func parseGitHubMerge(message string) (MergeMessage, error) {
+	match := githubMergeRegex.FindStringSubmatch(message)
+	if match == nil {
+		return MergeMessage{}, ErrNoSourceBranch
+	}
+
+	source := match[githubMergeRegex.SubexpIndex("source")]
+
+	splitted := strings.SplitN(source, "/", 2)
+
+	if len(splitted) < 2 {
+		return MergeMessage{}, fmt.Errorf("commit message does not contain expected format: %s", source)
+	}
+
+	return MergeMessage{
+		SourceBranch: splitted[1],
+		PRNumber:     atoi(match[githubMergeRegex.SubexpIndex("number")]),
+	}, nil
+}
+
+// parseGitLabMerge parses `Merge branch 'x' into 'y'` and the `See merge request group/project!N` trailer.
+func parseGitLabMerge(message string) (MergeMessage, error) {
+	match := gitlabMergeRegex.FindStringSubmatch(message)
+	if match == nil {
+		return MergeMessage{}, ErrNoSourceBranch
+	}
+
+	var number int
+
+	if request := gitlabRequestRegex.FindStringSubmatch(message); request != nil {
+		number = atoi(request[gitlabRequestRegex.SubexpIndex("number")])
+	}
+
+	return MergeMessage{
+		SourceBranch: match[gitlabMergeRegex.SubexpIndex("source")],
+		PRNumber:     number,
+	}, nil
+}
+
+// parseBitbucketMerge parses `Merged in x (pull request #N)`.
+func parseBitbucketMerge(message string) (MergeMessage, error) {
+	match := bitbucketMergeRegex.FindStringSubmatch(message)
+	if match == nil {
+		return MergeMessage{}, ErrNoSourceBranch
+	}
+
+	return MergeMessage{
+		SourceBranch: match[bitbucketMergeRegex.SubexpIndex("source")],
+		PRNumber:     atoi(match[bitbucketMergeRegex.SubexpIndex("number")]),
+	}, nil
+}
+
+// parseAzureMerge parses `Merged PR N: title`. Azure DevOps only includes the source
+// branch when the title keeps the default `Merge x into y` form, otherwise only
+// the pull request number is returned along with ErrNoSourceBranch.
+func parseAzureMerge(message string) (MergeMessage, error) {
+	match := azureMergeRegex.FindStringSubmatch(message)
+	if match == nil {
+		return MergeMessage{}, ErrNoSourceBranch
+	}
+
+	number := atoi(match[azureMergeRegex.SubexpIndex("number")])
+
+	title := azureTitleRegex.FindStringSubmatch(match[azureMergeRegex.SubexpIndex("title")])
+	if title == nil {
+		return MergeMessage{PRNumber: number}, ErrNoSourceBranch
+	}
+
+	return MergeMessage{
+		SourceBranch: strings.TrimPrefix(title[azureTitleRegex.SubexpIndex("source")], "refs/heads/"),
+		PRNumber:     number,
+	}, nil
+}
+
+// parseGitMerge parses the plain `git merge` message `Merge branch 'x'`.
+func parseGitMerge(message string) (MergeMessage, error) {
+	match := gitMergeRegex.FindStringSubmatch(message)
+	if match == nil {
+		return MergeMessage{}, ErrNoSourceBranch
+	}
+
+	source := match[gitMergeRegex.SubexpIndex("source")]
+
+	// remote-tracking branches are prefixed with the remote name
+	if match[gitMergeRegex.SubexpIndex("remote")] != "" {
+		if _, branch, ok := strings.Cut(source, "/"); ok {
+			source = branch
+		}
+	}
+
+	return MergeMessage{SourceBranch: source}, nil
+}
+
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
@@ -0,0 +1,169 @@
+package git_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/semver-action/pkg/git"
+
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMergeMessage(t *testing.T) {
+	tests := map[string]struct {
+		Format   string
+		Message  string
+		Expected git.MergeMessage
+	}{
+		"github": {
+			Format:  "github",
+			Message: "Merge pull request #123 from gandarez/feature/semver-initial\n\nSome description",
+			Expected: git.MergeMessage{
+				SourceBranch: "feature/semver-initial",
+				PRNumber:     123,
+			},
+		},
+		"gitlab": {
+			Format:  "gitlab",
+			Message: "Merge branch 'feature/semver-initial' into 'develop'\n\nSome description\n\nSee merge request group/project!45",
+			Expected: git.MergeMessage{
+				SourceBranch: "feature/semver-initial",
+				PRNumber:     45,
+			},
+		},
+		"gitlab without merge request": {
+			Format:  "gitlab",
+			Message: "Merge branch 'feature/semver-initial' into 'develop'",
+			Expected: git.MergeMessage{
+				SourceBranch: "feature/semver-initial",
+			},
+		},
+		"bitbucket": {
+			Format:  "bitbucket",
+			Message: "Merged in bugfix/some (pull request #7)\n\nFix some bug",
+			Expected: git.MergeMessage{
+				SourceBranch: "bugfix/some",
+				PRNumber:     7,
+			},
+		},
+		"azure": {
+			Format:  "azure",
+			Message: "Merged PR 89: Merge release/2.0 to main",
+			Expected: git.MergeMessage{
+				SourceBranch: "release/2.0",
+				PRNumber:     89,
+			},
+		},
+		"azure with refs": {
+			Format:  "azure",
+			Message: "Merged PR 90: Merge refs/heads/hotfix/some into refs/heads/main",
+			Expected: git.MergeMessage{
+				SourceBranch: "hotfix/some",
+				PRNumber:     90,
+			},
+		},
+		"git": {
+			Format:  "git",
+			Message: "Merge branch 'develop'",
+			Expected: git.MergeMessage{
+				SourceBranch: "develop",
+			},
+		},
+		"git into branch": {
+			Format:  "git",
+			Message: "Merge branch 'feature/some' into develop",
+			Expected: git.MergeMessage{
+				SourceBranch: "feature/some",
+			},
+		},
+		"git remote-tracking": {
+			Format:  "git",
+			Message: "Merge remote-tracking branch 'origin/hotfix/some'",
+			Expected: git.MergeMessage{
+				SourceBranch: "hotfix/some",
+			},
+		},
+		"auto github": {
+			Format:  "auto",
+			Message: "Merge pull request #123 from gandarez/feature/semver-initial",
+			Expected: git.MergeMessage{
+				SourceBranch: "feature/semver-initial",
+				PRNumber:     123,
+			},
+		},
+		"auto gitlab": {
+			Message: "Merge branch 'feature/semver-initial' into 'develop'\n\nSee merge request group/project!45",
+			Expected: git.MergeMessage{
+				SourceBranch: "feature/semver-initial",
+				PRNumber:     45,
+			},
+		},
+		"auto bitbucket": {
+			Format:  "auto",
+			Message: "Merged in bugfix/some (pull request #7)",
+			Expected: git.MergeMessage{
+				SourceBranch: "bugfix/some",
+				PRNumber:     7,
+			},
+		},
+		"auto git": {
+			Format:  "auto",
+			Message: "Merge branch 'feature/some' into develop",
+			Expected: git.MergeMessage{
+				SourceBranch: "feature/some",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := git.ParseMergeMessage(test.Format, test.Message)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, parsed)
+		})
+	}
+}
+
+func TestParseMergeMessage_NoSourceBranch(t *testing.T) {
+	tests := map[string]struct {
+		Format  string
+		Message string
+	}{
+		"auto":                 {Format: "auto", Message: "fix: some bug"},
+		"github with gitlab":   {Format: "github", Message: "Merge branch 'feature/some' into 'develop'"},
+		"gitlab with git":      {Format: "gitlab", Message: "Merge branch 'feature/some' into develop"},
+		"bitbucket with plain": {Format: "bitbucket", Message: "Merged bugfix/some"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := git.ParseMergeMessage(test.Format, test.Message)
+
+			assert.EqualError(t, err, "no source branch found")
+		})
+	}
+}
+
+func TestParseMergeMessage_AzureWithoutSourceBranch(t *testing.T) {
+	tests := map[string]string{
+		"azure": "azure",
+		"auto":  "auto",
+	}
+
+	for name, format := range tests {
+		t.Run(name, func(t *testing.T) {
+			parsed, err := git.ParseMergeMessage(format, "Merged PR 89: Add semver action")
+
+			require.ErrorIs(t, err, git.ErrNoSourceBranch)
+			assert.Equal(t, 89, parsed.PRNumber)
+			assert.Empty(t, parsed.SourceBranch)
+		})
+	}
+}
+
+func TestParseMergeMessage_UnsupportedFormat(t *testing.T) {
+	_, err := git.ParseMergeMessage("svn", "Merge pull request #123 from gandarez/feature/semver-initial")
+
+	assert.EqualError(t, err, "unsupported merge message format: svn")
+}
@@ -0,0 +1,64 @@
+package git_test
+
+import (
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gandarez/semver-action/pkg/git"
+
+	"github.com/alecthomas/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateTag_PushTag_BareRemote(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not found")
+	}
+
+	tmp := t.TempDir()
+	remoteDir := filepath.Join(tmp, "remote.git")
+	repoDir := filepath.Join(tmp, "repo")
+
+	runGit(t, tmp, "init", "--bare", remoteDir)
+	runGit(t, tmp, "init", repoDir)
+	runGit(t, repoDir, "-c", "user.name=test", "-c", "user.email=test@example.com",
+		"commit", "--allow-empty", "-m", "initial commit")
+	runGit(t, repoDir, "remote", "add", "origin", remoteDir)
+
+	commitHash := runGit(t, repoDir, "rev-parse", "HEAD")
+
+	gc := git.New(repoDir)
+
+	require.NoError(t, gc.CreateTag("v1.0.0", commitHash, ""))
+	require.NoError(t, gc.CreateTag("v1.1.0", commitHash, "Release v1.1.0"))
+	require.NoError(t, gc.PushTag("origin", "v1.0.0"))
+	require.NoError(t, gc.PushTag("origin", "v1.1.0"))
+
+	assert.Equal(t, "commit", runGit(t, remoteDir, "cat-file", "-t", "refs/tags/v1.0.0"))
+	assert.Equal(t, "tag", runGit(t, remoteDir, "cat-file", "-t", "refs/tags/v1.1.0"))
+	assert.Equal(t, commitHash, runGit(t, remoteDir, "rev-parse", "v1.1.0^{commit}"))
+
+	// never overwrite an existing tag, neither locally nor in the remote
+	assert.EqualError(t, gc.CreateTag("v1.0.0", commitHash, ""), "tag v1.0.0 already exists")
+
+	runGit(t, repoDir, "-c", "user.name=test", "-c", "user.email=test@example.com",
+		"commit", "--allow-empty", "-m", "second commit")
+	runGit(t, repoDir, "tag", "--delete", "v1.0.0")
+	runGit(t, repoDir, "tag", "v1.0.0")
+
+	err := gc.PushTag("origin", "v1.0.0")
+	require.Error(t, err)
+
+	assert.Equal(t, commitHash, runGit(t, remoteDir, "rev-parse", "v1.0.0^{commit}"))
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...) // nolint:gosec
+
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+
+	return strings.TrimSpace(string(out))
+}
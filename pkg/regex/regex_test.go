@@ -3,7 +3,7 @@ package regex_test
 import (
 	"testing"
 
-	"github.com/gandarez/semver-action/internal/regex"
+	"github.com/gandarez/semver-action/pkg/regex"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -0,0 +1,285 @@
+package strategy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/regex"
+)
+
+// calVerTokens contains the calendar tokens of the calver format. The format
+// ends with the MICRO or N counter, reset whenever the calendar part changes.
+// nolint: gochecknoglobals
+var calVerTokens = []string{"YYYY", "YY", "0Y", "MM", "0M", "WW", "0W", "DD", "0D"}
+
+// CalVerFormat is a parsed calver format, e.g. YYYY.0M.MICRO.
+type CalVerFormat struct {
+	calendar []string
+	counter  string
+	week     bool
+}
+
+// ParseCalVerFormat parses a calver format made of dot separated calendar
+// tokens followed by the MICRO or N counter, e.g. YYYY.MM.MICRO or YY.0W.N.
+func ParseCalVerFormat(s string) (CalVerFormat, error) {
+	segments := strings.Split(s, ".")
+	if len(segments) < 2 {
+		return CalVerFormat{}, fmt.Errorf("format %q must have at least one calendar token and a counter", s)
+	}
+
+	counter := segments[len(segments)-1]
+	if counter != "MICRO" && counter != "N" {
+		return CalVerFormat{}, fmt.Errorf("format %q must end with MICRO or N", s)
+	}
+
+	format := CalVerFormat{
+		calendar: segments[:len(segments)-1],
+		counter:  counter,
+	}
+
+	for _, token := range format.calendar {
+		if !stringInSlice(token, calVerTokens) {
+			return CalVerFormat{}, fmt.Errorf("format %q has invalid token %q", s, token)
+		}
+
+		if token == "WW" || token == "0W" {
+			format.week = true
+		}
+	}
+
+	return format, nil
+}
+
+// String returns the format.
+func (f CalVerFormat) String() string {
+	if len(f.calendar) == 0 {
+		return ""
+	}
+
+	return strings.Join(f.calendar, ".") + "." + f.counter
+}
+
+// Calendar returns the calendar part of the version at t.
+func (f CalVerFormat) Calendar(t time.Time) string {
+	year := t.Year()
+	_, week := t.ISOWeek()
+
+	// the first days of January can belong to the last week of the previous year
+	if f.week {
+		year, week = t.ISOWeek()
+	}
+
+	parts := make([]string, len(f.calendar))
+
+	for i, token := range f.calendar {
+		switch token {
+		case "YYYY":
+			parts[i] = strconv.Itoa(year)
+		case "YY":
+			parts[i] = strconv.Itoa(year % 100)
+		case "0Y":
+			parts[i] = fmt.Sprintf("%02d", year%100)
+		case "MM":
+			parts[i] = strconv.Itoa(int(t.Month()))
+		case "0M":
+			parts[i] = fmt.Sprintf("%02d", int(t.Month()))
+		case "WW":
+			parts[i] = strconv.Itoa(week)
+		case "0W":
+			parts[i] = fmt.Sprintf("%02d", week)
+		case "DD":
+			parts[i] = strconv.Itoa(t.Day())
+		case "0D":
+			parts[i] = fmt.Sprintf("%02d", t.Day())
+		}
+	}
+
+	return strings.Join(parts, ".")
+}
+
+// CalVer implements the calendar versioning strategy. Merges into the main
+// branch are released, any other branch gets prereleases.
+type CalVer struct {
+	branchName     string
+	format         CalVerFormat
+	excludePattern regex.Regex
+	// labelBumps maps pull request labels to major, minor, patch or skip.
+	labelBumps map[string]string
+	now        func() time.Time
+}
+
+// NewCalVer returns the calver strategy, registered as "calver".
+func NewCalVer(config Configuration) (Strategy, error) {
+	format, err := ParseCalVerFormat(config.CalVerFormat)
+	if err != nil {
+		return nil, fmt.Errorf("invalid calver format: %s", err)
+	}
+
+	now := config.Now
+	if now == nil {
+		now = func() time.Time { return time.Now().UTC() }
+	}
+
+	return &CalVer{
+		branchName:     config.MainBranchName,
+		format:         format,
+		excludePattern: config.ExcludePattern,
+		labelBumps:     config.LabelBumps,
+		now:            now,
+	}, nil
+}
+
+// DetermineBumpStrategy determines the strategy for semver to bump product version.
+func (c *CalVer) DetermineBumpStrategy(params BumpParams) (string, string) {
+	// if source branch is excluded, do not bump
+	if c.excludePattern != nil && c.excludePattern.MatchString(params.SourceBranch) {
+		params.Trace.Decide("source branch matches exclude pattern", c.excludePattern)
+		return "", ""
+	}
+
+	labelBump, label := labelsBump(params.Labels, c.labelBumps)
+
+	// if a pull request label skips the bump, do not bump, other labels don't
+	// apply to calendar versions
+	if labelBump == "skip" {
+		params.Trace.Decide(fmt.Sprintf("pull request label %s skips the bump", label), nil)
+		return "", ""
+	}
+
+	noteIgnoredLabel(params.Trace, label, "by calver model")
+
+	if params.DestBranch == c.branchName {
+		params.Trace.Decide("dest branch is main branch", nil)
+		return "release", ""
+	}
+
+	params.Trace.Decide("dest branch is not main branch", nil)
+
+	return "prerelease", ""
+}
+
+// Tag implements the Strategy interface. It reads the latest tag from
+// LatestTag, as calendar versions are not always valid semantic versions.
+func (c *CalVer) Tag(params TagParams, gc git.Git) (Result, error) {
+	calendar := c.format.Calendar(c.now())
+
+	latest := strings.TrimPrefix(params.LatestTag, params.Prefix)
+
+	// prerelease and build suffixes don't count for the calendar part and counter
+	latestFinal := latest
+	if i := strings.IndexAny(latestFinal, "-+"); i >= 0 {
+		latestFinal = latestFinal[:i]
+	}
+
+	counter := 0
+
+	latestCalendar, latestCounter, ok := c.split(latestFinal)
+
+	switch {
+	case ok && sameCalendar(latestCalendar, calendar) && latestFinal != latest:
+		// finish the version the latest prerelease was for
+		counter = latestCounter
+	case ok && sameCalendar(latestCalendar, calendar):
+		counter = latestCounter + 1
+	case params.LatestTag != "":
+		params.Trace.Notef("calendar changed since latest tag, counter reset")
+	}
+
+	version := fmt.Sprintf("%s.%d", calendar, counter)
+
+	params.Trace.Increment(fmt.Sprintf("calendar %s", c.format), latest, version)
+
+	if params.Method != "prerelease" {
+		return Result{
+			SemverTag:    params.Prefix + version,
+			IsPrerelease: false,
+		}, nil
+	}
+
+	// prereleases of the same version are numbered in sequence
+	prereleasePrefix := fmt.Sprintf("%s%s-%s.", params.Prefix, version, params.PrereleaseID)
+
+	tags, err := gc.Tags(prereleasePrefix + "*")
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to list prerelease tags: %s", err)
+	}
+
+	var prereleaseNumber int
+
+	for _, tag := range tags {
+		n, err := strconv.Atoi(strings.TrimPrefix(tag, prereleasePrefix))
+		if err == nil && n > prereleaseNumber {
+			prereleaseNumber = n
+		}
+	}
+
+	prerelease := fmt.Sprintf("%s-%s.%d", version, params.PrereleaseID, prereleaseNumber+1)
+
+	params.Trace.Increment("set prerelease number", version, prerelease)
+
+	return Result{
+		SemverTag:    params.Prefix + prerelease,
+		IsPrerelease: true,
+	}, nil
+}
+
+// split splits the version into its calendar part and counter.
+func (c *CalVer) split(version string) (string, int, bool) {
+	segments := strings.Split(version, ".")
+	if len(segments) != len(c.format.calendar)+1 {
+		return "", 0, false
+	}
+
+	counter, err := strconv.Atoi(segments[len(segments)-1])
+	if err != nil {
+		return "", 0, false
+	}
+
+	return strings.Join(segments[:len(segments)-1], "."), counter, true
+}
+
+// Features implements the Describer interface.
+func (*CalVer) Features() Features {
+	return Features{
+		Methods:  []string{"release", "prerelease"},
+		Calendar: true,
+	}
+}
+
+// Name returns the name of the strategy.
+func (CalVer) Name() string {
+	return "calver"
+}
+
+// sameCalendar compares the calendar parts numerically, so a tag created with
+// a zero padded format still matches.
+func sameCalendar(a, b string) bool {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	if len(as) != len(bs) {
+		return false
+	}
+
+	for i := range as {
+		x, errx := strconv.Atoi(as[i])
+		y, erry := strconv.Atoi(bs[i])
+
+		if errx != nil || erry != nil || x != y {
+			return false
+		}
+	}
+
+	return true
+}
+
+func stringInSlice(a string, list []string) bool {
+	for _, b := range list {
+		if b == a {
+			return true
+		}
+	}
+
+	return false
+}
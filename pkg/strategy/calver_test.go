@@ -0,0 +1,274 @@
+package strategy_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gandarez/semver-action/pkg/regex"
+	"github.com/gandarez/semver-action/pkg/strategy"
+	"github.com/gandarez/semver-action/pkg/trace"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCalVerFormat(t *testing.T) {
+	tests := map[string]struct {
+		Format   string
+		Time     time.Time
+		Expected string
+	}{
+		"year and month": {
+			Format:   "YYYY.MM.MICRO",
+			Time:     time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+			Expected: "2024.6",
+		},
+		"zero padded year and month": {
+			Format:   "0Y.0M.MICRO",
+			Time:     time.Date(2005, 6, 3, 0, 0, 0, 0, time.UTC),
+			Expected: "05.06",
+		},
+		"short year and zero padded week": {
+			Format:   "YY.0W.N",
+			Time:     time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			Expected: "24.05",
+		},
+		"week of previous year": {
+			Format:   "YYYY.WW.N",
+			Time:     time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC),
+			Expected: "2020.53",
+		},
+		"day": {
+			Format:   "YYYY.0M.0D.MICRO",
+			Time:     time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC),
+			Expected: "2024.06.03",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			format, err := strategy.ParseCalVerFormat(test.Format)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Format, format.String())
+			assert.Equal(t, test.Expected, format.Calendar(test.Time))
+		})
+	}
+}
+
+func TestParseCalVerFormat_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"no counter":     "YYYY.MM",
+		"only counter":   "MICRO",
+		"invalid token":  "YYYY.QQ.MICRO",
+		"counter middle": "YYYY.MICRO.MM",
+	}
+
+	for name, format := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := strategy.ParseCalVerFormat(format)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDetermineBumpStrategy_CalVer(t *testing.T) {
+	tests := map[string]struct {
+		SourceBranch   string
+		DestBranch     string
+		Labels         []string
+		ExpectedMethod string
+		ExpectedNotes  []string
+	}{
+		"dest branch master": {
+			SourceBranch:   "feature/some",
+			DestBranch:     "master",
+			ExpectedMethod: "release",
+		},
+		"dest branch other": {
+			SourceBranch:   "feature/some",
+			DestBranch:     "develop",
+			ExpectedMethod: "prerelease",
+		},
+		"source branch ignore": {
+			SourceBranch:   "ignore/some",
+			DestBranch:     "master",
+			ExpectedMethod: "",
+		},
+		"skip label": {
+			SourceBranch: "feature/some",
+			DestBranch:   "master",
+			Labels:       []string{"semver:major", "semver:skip"},
+		},
+		"labels are ignored": {
+			SourceBranch:   "feature/some",
+			DestBranch:     "master",
+			Labels:         []string{"semver:major"},
+			ExpectedMethod: "release",
+			ExpectedNotes:  []string{"pull request label semver:major is ignored by calver model"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				BranchingModel: "calver",
+				MainBranchName: "master",
+				CalVerFormat:   "YYYY.MM.MICRO",
+				ExcludePattern: regex.MustCompile(`(?i)^ignore/.+`),
+				LabelBumps: map[string]string{
+					"semver:major": "major",
+					"semver:skip":  "skip",
+				},
+			})
+			require.NoError(t, err)
+
+			tr := &trace.Trace{}
+
+			method, version := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+				SourceBranch: test.SourceBranch,
+				DestBranch:   test.DestBranch,
+				Labels:       test.Labels,
+				Trace:        tr,
+			})
+
+			assert.Equal(t, test.ExpectedMethod, method)
+			assert.Empty(t, version)
+			assert.Equal(t, test.ExpectedNotes, tr.Notes)
+		})
+	}
+}
+
+func TestTag_CalVer(t *testing.T) {
+	tests := map[string]struct {
+		Method          string
+		LatestTag       string
+		PrereleaseTags  []string
+		ExpectedPattern string
+		Expected        strategy.Result
+	}{
+		"first release": {
+			Method: "release",
+			Expected: strategy.Result{
+				SemverTag: "v2024.6.0",
+			},
+		},
+		"same month": {
+			Method:    "release",
+			LatestTag: "v2024.6.2",
+			Expected: strategy.Result{
+				SemverTag: "v2024.6.3",
+			},
+		},
+		"same month zero padded tag": {
+			Method:    "release",
+			LatestTag: "v2024.06.2",
+			Expected: strategy.Result{
+				SemverTag: "v2024.6.3",
+			},
+		},
+		"month changed": {
+			Method:    "release",
+			LatestTag: "v2024.5.7",
+			Expected: strategy.Result{
+				SemverTag: "v2024.6.0",
+			},
+		},
+		"release of latest prerelease": {
+			Method:    "release",
+			LatestTag: "v2024.6.3-pre.2",
+			Expected: strategy.Result{
+				SemverTag: "v2024.6.3",
+			},
+		},
+		"latest tag not calver": {
+			Method:    "release",
+			LatestTag: "v1.2.3.4",
+			Expected: strategy.Result{
+				SemverTag: "v2024.6.0",
+			},
+		},
+		"first prerelease": {
+			Method:          "prerelease",
+			LatestTag:       "v2024.6.2",
+			ExpectedPattern: "v2024.6.3-pre.*",
+			Expected: strategy.Result{
+				SemverTag:    "v2024.6.3-pre.1",
+				IsPrerelease: true,
+			},
+		},
+		"next prerelease": {
+			Method:          "prerelease",
+			LatestTag:       "v2024.6.3-pre.1",
+			PrereleaseTags:  []string{"v2024.6.3-pre.1", "v2024.6.3-pre.2"},
+			ExpectedPattern: "v2024.6.3-pre.*",
+			Expected: strategy.Result{
+				SemverTag:    "v2024.6.3-pre.3",
+				IsPrerelease: true,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gc := &gitClientMock{
+				TagsFn: func(pattern string) ([]string, error) {
+					assert.Equal(t, test.ExpectedPattern, pattern)
+					return test.PrereleaseTags, nil
+				},
+			}
+
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				BranchingModel: "calver",
+				CalVerFormat:   "YYYY.MM.MICRO",
+				Now: func() time.Time {
+					return time.Date(2024, 6, 18, 10, 0, 0, 0, time.UTC)
+				},
+			})
+			require.NoError(t, err)
+
+			result, err := branchingStrategy.Tag(strategy.TagParams{
+				Method:       test.Method,
+				Prefix:       "v",
+				PrereleaseID: "pre",
+				LatestTag:    test.LatestTag,
+			}, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result)
+		})
+	}
+}
+
+func TestTag_CalVer_TagsErr(t *testing.T) {
+	gc := &gitClientMock{
+		TagsFn: func(pattern string) ([]string, error) {
+			return nil, errors.New("error")
+		},
+	}
+
+	branchingStrategy, err := strategy.New(strategy.Configuration{
+		BranchingModel: "calver",
+		CalVerFormat:   "YYYY.MM.MICRO",
+	})
+	require.NoError(t, err)
+
+	_, err = branchingStrategy.Tag(strategy.TagParams{
+		Method:       "prerelease",
+		Prefix:       "v",
+		PrereleaseID: "pre",
+	}, gc)
+
+	assert.EqualError(t, err, "failed to list prerelease tags: error")
+}
+
+func TestNew_CalVerInvalidFormat(t *testing.T) {
+	_, err := strategy.New(strategy.Configuration{
+		BranchingModel: "calver",
+		CalVerFormat:   "YYYY",
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid calver format")
+}
@@ -0,0 +1,100 @@
+package strategy
+
+import "fmt"
+
+type (
+	// Features describes the model specific behavior of a strategy, so callers
+	// don't depend on the names of the branching models. The zero value
+	// describes a strategy bumping semantic versions by major, minor and patch.
+	Features struct {
+		// Methods are the bump methods Tag supports. Empty means major, minor
+		// and patch.
+		Methods []string
+		// BuildVersions is true if the build method carries the version part
+		// the build prepares, e.g. git-flow builds of feature branches.
+		BuildVersions bool
+		// BuildBranches are the dest branches the build method applies to,
+		// e.g. develop in git-flow. Empty means any dest branch.
+		BuildBranches []string
+		// PreviewTagPattern matches the tags of pull request previews after the
+		// prefix, e.g. `*-pr.*`. When set, pull requests not merged yet are
+		// released as previews, and their tags are excluded from the latest tag
+		// lookup unless an exclude tag pattern is set.
+		PreviewTagPattern string
+		// MaintenanceLines is true if dest branches matching the maintenance
+		// pattern only look up the tags of their version line.
+		MaintenanceLines bool
+		// Calendar is true if versions are calendar versions, which are not
+		// always valid semantic versions. Tag reads the latest tag itself and
+		// checks of the bumped version part don't apply.
+		Calendar bool
+		// Rules is true if the strategy evaluates the configured rules.
+		Rules bool
+	}
+
+	// Describer is implemented by strategies having model specific features.
+	Describer interface {
+		Features() Features
+	}
+)
+
+// FeaturesOf returns the features of the strategy, or the zero value if it
+// doesn't implement Describer.
+func FeaturesOf(s Strategy) Features {
+	if d, ok := s.(Describer); ok {
+		return d.Features()
+	}
+
+	return Features{}
+}
+
+// Supports returns true if Tag supports the bump method.
+func (f Features) Supports(method string) bool {
+	methods := f.Methods
+	if len(methods) == 0 {
+		methods = []string{"major", "minor", "patch"}
+	}
+
+	for _, m := range methods {
+		if m == method {
+			return true
+		}
+	}
+
+	return false
+}
+
+// BuildsInto returns true if Tag supports the build method into the dest branch.
+func (f Features) BuildsInto(dest string) bool {
+	if !f.Supports("build") {
+		return false
+	}
+
+	if len(f.BuildBranches) == 0 {
+		return true
+	}
+
+	for _, b := range f.BuildBranches {
+		if b == dest {
+			return true
+		}
+	}
+
+	return false
+}
+
+// validateRules returns an error if the strategy doesn't evaluate rules, or a
+// rule has a method Tag doesn't support. Empty methods mean no bump.
+func (f Features) validateRules(model string, rules Rules) error {
+	if len(rules) > 0 && !f.Rules {
+		return fmt.Errorf("rules are not supported by %s model", model)
+	}
+
+	for i, rule := range rules {
+		if rule.Method != "" && !f.Supports(rule.Method) {
+			return fmt.Errorf("rule at index %d has method %q not supported by %s model", i, rule.Method, model)
+		}
+	}
+
+	return nil
+}
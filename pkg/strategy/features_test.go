@@ -0,0 +1,161 @@
+package strategy_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/semver-action/pkg/regex"
+	"github.com/gandarez/semver-action/pkg/strategy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeaturesOf(t *testing.T) {
+	tests := map[string]struct {
+		BranchingModel string
+		Expected       strategy.Features
+	}{
+		"git-flow": {
+			BranchingModel: "git-flow",
+			Expected: strategy.Features{
+				Methods:       []string{"build", "major", "minor", "patch", "hotfix", "graduate", "final"},
+				BuildVersions: true,
+				BuildBranches: []string{"develop"},
+				Rules:         true,
+			},
+		},
+		"trunk-based": {
+			BranchingModel: "trunk-based",
+			Expected: strategy.Features{
+				Methods:          []string{"build", "major", "minor", "patch", "graduate"},
+				BuildBranches:    []string{"master"},
+				MaintenanceLines: true,
+				Rules:            true,
+			},
+		},
+		"github-flow": {
+			BranchingModel: "github-flow",
+			Expected: strategy.Features{
+				Methods:           []string{"major", "minor", "patch", "graduate"},
+				PreviewTagPattern: "*-pr.*",
+				Rules:             true,
+			},
+		},
+		"calver": {
+			BranchingModel: "calver",
+			Expected: strategy.Features{
+				Methods:  []string{"release", "prerelease"},
+				Calendar: true,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				Bump:              "auto",
+				BranchingModel:    test.BranchingModel,
+				MainBranchName:    "master",
+				DevelopBranchName: "develop",
+				CalVerFormat:      "YYYY.0M.MICRO",
+			})
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, strategy.FeaturesOf(branchingStrategy))
+		})
+	}
+}
+
+func TestFeaturesOf_NotDescriber(t *testing.T) {
+	features := strategy.FeaturesOf(fixedStrategy{})
+
+	assert.Equal(t, strategy.Features{}, features)
+	assert.True(t, features.Supports("minor"))
+	assert.False(t, features.Supports("build"))
+}
+
+func TestFeatures_Supports(t *testing.T) {
+	features := strategy.Features{Methods: []string{"release", "prerelease"}}
+
+	assert.True(t, features.Supports("release"))
+	assert.False(t, features.Supports("major"))
+}
+
+func TestFeatures_BuildsInto(t *testing.T) {
+	tests := map[string]struct {
+		Features strategy.Features
+		Dest     string
+		Expected bool
+	}{
+		"build branch": {
+			Features: strategy.Features{Methods: []string{"build", "patch"}, BuildBranches: []string{"develop"}},
+			Dest:     "develop",
+			Expected: true,
+		},
+		"other branch": {
+			Features: strategy.Features{Methods: []string{"build", "patch"}, BuildBranches: []string{"develop"}},
+			Dest:     "master",
+		},
+		"any branch": {
+			Features: strategy.Features{Methods: []string{"build", "patch"}},
+			Dest:     "master",
+			Expected: true,
+		},
+		"no build method": {
+			Features: strategy.Features{BuildBranches: []string{"master"}},
+			Dest:     "master",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.Expected, test.Features.BuildsInto(test.Dest))
+		})
+	}
+}
+
+func TestNew_InvalidRules(t *testing.T) {
+	tests := map[string]struct {
+		BranchingModel string
+		Rules          strategy.Rules
+		ExpectedError  string
+	}{
+		"hotfix in trunk-based": {
+			BranchingModel: "trunk-based",
+			Rules: strategy.Rules{
+				{Source: regex.MustCompile(`^chore/.+`)},
+				{Source: regex.MustCompile(`^fix/.+`), Method: "hotfix"},
+			},
+			ExpectedError: `rule at index 1 has method "hotfix" not supported by trunk-based model`,
+		},
+		"build in github-flow": {
+			BranchingModel: "github-flow",
+			Rules:          strategy.Rules{{Method: "build"}},
+			ExpectedError:  `rule at index 0 has method "build" not supported by github-flow model`,
+		},
+		"final in trunk-based": {
+			BranchingModel: "trunk-based",
+			Rules:          strategy.Rules{{Method: "final"}},
+			ExpectedError:  `rule at index 0 has method "final" not supported by trunk-based model`,
+		},
+		"rules in calver": {
+			BranchingModel: "calver",
+			Rules:          strategy.Rules{{Method: "patch"}},
+			ExpectedError:  "rules are not supported by calver model",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := strategy.New(strategy.Configuration{
+				Bump:           "auto",
+				BranchingModel: test.BranchingModel,
+				CalVerFormat:   "YYYY.0M.MICRO",
+				Rules:          test.Rules,
+			})
+			require.Error(t, err)
+
+			assert.Equal(t, test.ExpectedError, err.Error())
+		})
+	}
+}
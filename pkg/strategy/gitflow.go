@@ -0,0 +1,313 @@
+package strategy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/blang/semver/v4"
+
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/regex"
+)
+
+// GitFlow implements the git-flow strategy.
+type GitFlow struct {
+	bump              string
+	bumpSource        string
+	developBranchName string
+	mainBranchName    string
+	excludePattern    regex.Regex
+	// labelBumps maps pull request labels to major, minor, patch or skip.
+	labelBumps map[string]string
+	// rules are the configured rules followed by the built-in ones.
+	rules Rules
+	// initialDevelopment downgrades major to minor and minor to patch while
+	// the major version is zero.
+	initialDevelopment bool
+}
+
+// NewGitFlow returns the git-flow strategy, registered as "git-flow".
+func NewGitFlow(config Configuration) (Strategy, error) {
+	return &GitFlow{
+		bump:               config.Bump,
+		bumpSource:         config.BumpSource,
+		developBranchName:  config.DevelopBranchName,
+		mainBranchName:     config.MainBranchName,
+		excludePattern:     config.ExcludePattern,
+		labelBumps:         config.LabelBumps,
+		rules:              append(append(Rules{}, config.Rules...), gitFlowRules(config)...),
+		initialDevelopment: config.InitialDevelopment,
+	}, nil
+}
+
+// gitFlowRules returns the built-in rules of git-flow model.
+func gitFlowRules(config Configuration) Rules {
+	develop, main := branch(config.DevelopBranchName), branch(config.MainBranchName)
+
+	return Rules{
+		// bugfix into develop branch
+		{
+			Source:      config.PatchPattern,
+			Dest:        develop,
+			Method:      "build",
+			Version:     "patch",
+			Description: "source branch matches patch pattern into develop branch",
+		},
+		// feature into develop
+		{
+			Source:      config.MinorPattern,
+			Dest:        develop,
+			Method:      "build",
+			Version:     "minor",
+			Description: "source branch matches minor pattern into develop branch",
+		},
+		// major into develop
+		{
+			Source:      config.MajorPattern,
+			Dest:        develop,
+			Method:      "build",
+			Version:     "major",
+			Description: "source branch matches major pattern into develop branch",
+		},
+		// build into develop branch
+		{
+			Source:      config.BuildPattern,
+			Dest:        develop,
+			Method:      "build",
+			Version:     "build",
+			Description: "source branch matches build pattern into develop branch",
+		},
+		// hotfix into main branch
+		{
+			Source:      config.HotfixPattern,
+			Dest:        main,
+			Method:      "hotfix",
+			Description: "source branch matches hotfix pattern into main branch",
+		},
+		// develop branch into main branch
+		{
+			Source:      develop,
+			Dest:        main,
+			Method:      "final",
+			Description: "develop branch into main branch",
+		},
+	}.withSources()
+}
+
+// DetermineBumpStrategy determines the strategy for semver to bump product version.
+func (g *GitFlow) DetermineBumpStrategy(params BumpParams) (string, string) {
+	sourceBranch, destBranch := params.SourceBranch, params.DestBranch
+
+	// if source branch is excluded, do not bump
+	if g.excludePattern != nil && g.excludePattern.MatchString(sourceBranch) {
+		params.Trace.Decide("source branch matches exclude pattern", g.excludePattern)
+		return "", ""
+	}
+
+	labelBump, label := labelsBump(params.Labels, g.labelBumps)
+
+	// if a pull request label skips the bump, do not bump
+	if labelBump == "skip" {
+		params.Trace.Decide(fmt.Sprintf("pull request label %s skips the bump", label), nil)
+		return "", ""
+	}
+
+	// if bump is not auto, return it
+	if g.bump != "auto" {
+		noteIgnoredLabel(params.Trace, label, fmt.Sprintf("as bump is %s", g.bump))
+
+		params.Trace.Decide(fmt.Sprintf("bump is %s", g.bump), nil)
+		return g.bump, ""
+	}
+
+	// pull request labels take precedence over commits and branch patterns
+	if labelBump != "" && destBranch == g.developBranchName {
+		params.Trace.Decide(fmt.Sprintf("pull request label %s requires %s into develop branch", label, labelBump), nil)
+		return "build", labelBump
+	}
+
+	noteIgnoredLabel(params.Trace, label, fmt.Sprintf("into %s branch", destBranch))
+
+	// conventional commits into develop branch, falls back to branch patterns
+	if g.bumpSource == "commits" && destBranch == g.developBranchName {
+		if version := commitsBump(params.Commits); version != "" {
+			params.Trace.Decide(fmt.Sprintf("conventional commits require %s into develop branch", version), nil)
+			return "build", version
+		}
+	}
+
+	if method, version, ok := g.rules.Decide(params); ok {
+		return method, version
+	}
+
+	params.Trace.Decide("source branch matches no pattern", nil)
+
+	return "build", ""
+}
+
+// Tag implements the Strategy interface.
+func (g *GitFlow) Tag(params TagParams, gc git.Git) (Result, error) {
+	if g.initialDevelopment {
+		if params.Method == "build" {
+			params.Version = initialDevelopmentLevel(params.Version, params.Tag, params.Trace)
+		} else {
+			params.Method = initialDevelopmentLevel(params.Method, params.Tag, params.Trace)
+		}
+	}
+
+	if params.Method == "graduate" {
+		if err := graduate(params); err != nil {
+			return Result{}, err
+		}
+	}
+
+	if (params.Version == "major" && params.Method == "build") || params.Method == "major" {
+		log.Debug("incrementing major")
+
+		before := params.Tag.String()
+
+		if err := params.Tag.IncrementMajor(); err != nil {
+			return Result{}, fmt.Errorf("failed to increment major version: %s", err)
+		}
+
+		params.Trace.Increment("increment major", before, params.Tag.String())
+	}
+
+	if (params.Version == "minor" && params.Method == "build") || params.Method == "minor" {
+		log.Debug("incrementing minor")
+
+		before := params.Tag.String()
+
+		if err := params.Tag.IncrementMinor(); err != nil {
+			return Result{}, fmt.Errorf("failed to increment minor version: %s", err)
+		}
+
+		params.Trace.Increment("increment minor", before, params.Tag.String())
+	}
+
+	if (params.Version == "patch" && params.Method == "build") || params.Method == "patch" || params.Method == "hotfix" {
+		log.Debug("incrementing patch")
+
+		before := params.Tag.String()
+
+		if err := params.Tag.IncrementPatch(); err != nil {
+			return Result{}, fmt.Errorf("failed to increment patch version: %s", err)
+		}
+
+		params.Trace.Increment("increment patch", before, params.Tag.String())
+	}
+
+	// If branch matches the build pattern and the latest tag is equal to the
+	// ancestor develop tag excluding prerelease part, then it will use ancestor one instead.
+	if params.Version == "build" && params.Method == "build" {
+		log.Debug("using acestor tag")
+
+		ancestorDevelopTag := gc.AncestorTag(
+			fmt.Sprintf("%s[0-9]*-%s*", params.Prefix, params.PrereleaseID),
+			"",
+			params.DestBranch)
+
+		parsed, err := semver.ParseTolerant(strings.TrimPrefix(ancestorDevelopTag, params.Prefix))
+		if err != nil {
+			return Result{}, fmt.Errorf("failed to parse tag %q or not valid semantic version: %s", params.LatestTag, err)
+		}
+
+		if params.Tag.String() == parsed.FinalizeVersion() {
+			params.Trace.Increment("use ancestor develop tag "+ancestorDevelopTag, params.Tag.String(), parsed.String())
+			params.Tag = &parsed
+		}
+	}
+
+	var (
+		finalTag       string
+		includePattern string
+		excludePattern string
+		isPrerelease   bool
+	)
+
+	switch params.Method {
+	case "build":
+		{
+			isPrerelease = true
+			includePattern = fmt.Sprintf("%s[0-9]*-%s*", params.Prefix, params.PrereleaseID)
+
+			before := params.Tag.String()
+
+			buildNumber, _ := semver.NewPRVersion("0")
+
+			// the counter continues within the same channel, and starts over when
+			// the version is bumped or promoted to another channel
+			continues := params.Version == "" || params.Version == "build"
+
+			if len(params.Tag.Pre) > 0 && params.Tag.Pre[0].VersionStr != params.PrereleaseID && continues {
+				params.Trace.Notef("promoted from prerelease channel %s to %s", params.Tag.Pre[0].VersionStr, params.PrereleaseID)
+
+				continues = false
+			}
+
+			if len(params.Tag.Pre) > 1 && continues {
+				buildNumber = params.Tag.Pre[1]
+			}
+
+			params.Tag.Pre = nil
+
+			preVersion, err := semver.NewPRVersion(params.PrereleaseID)
+			if err != nil {
+				return Result{}, fmt.Errorf("failed to create new pre-release version: %s", err)
+			}
+
+			params.Tag.Pre = append(params.Tag.Pre, preVersion)
+
+			buildVersion, err := semver.NewPRVersion(strconv.Itoa(int(buildNumber.VersionNum + 1)))
+			if err != nil {
+				return Result{}, fmt.Errorf("failed to create new build version: %s", err)
+			}
+
+			params.Tag.Pre = append(params.Tag.Pre, buildVersion)
+
+			params.Trace.Increment("set prerelease build number", before, params.Tag.String())
+
+			finalTag = params.Prefix + params.Tag.String()
+		}
+	case "major", "minor", "patch", "graduate":
+		if len(params.Tag.Pre) > 0 {
+			isPrerelease = true
+			includePattern = fmt.Sprintf("%s[0-9]*-%s*", params.Prefix, params.PrereleaseID)
+		} else {
+			includePattern = fmt.Sprintf("%s[0-9]*", params.Prefix)
+			excludePattern = fmt.Sprintf("%s[0-9]*-*", params.Prefix)
+		}
+
+		finalTag = params.Prefix + params.Tag.String()
+	default:
+		// final versions never descend from prereleases of any channel
+		includePattern = fmt.Sprintf("%s[0-9]*", params.Prefix)
+		excludePattern = fmt.Sprintf("%s[0-9]*-*", params.Prefix)
+		finalTag = params.Prefix + params.Tag.FinalizeVersion()
+
+		params.Trace.Increment("finalize", params.Tag.String(), params.Tag.FinalizeVersion())
+	}
+
+	return Result{
+		AncestorTag:  gc.AncestorTag(includePattern, excludePattern, params.DestBranch),
+		SemverTag:    finalTag,
+		IsPrerelease: isPrerelease,
+	}, nil
+}
+
+// Features implements the Describer interface.
+func (g *GitFlow) Features() Features {
+	return Features{
+		Methods:       []string{"build", "major", "minor", "patch", "hotfix", "graduate", "final"},
+		BuildVersions: true,
+		BuildBranches: []string{g.developBranchName},
+		Rules:         true,
+	}
+}
+
+// Name returns the name of the strategy.
+func (GitFlow) Name() string {
+	return "git-flow"
+}
@@ -0,0 +1,700 @@
+package strategy_test
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/regex"
+	"github.com/gandarez/semver-action/pkg/strategy"
+	"github.com/gandarez/semver-action/pkg/trace"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetermineBumpStrategy_Gitflow(t *testing.T) {
+	tests := map[string]struct {
+		SourceBranch    string
+		DestBranch      string
+		Bump            string
+		ExcludePattern  regex.Regex
+		ExpectedMethod  string
+		ExpectedVersion string
+	}{
+		"source branch bugfix, dest branch develop and auto bump": {
+			SourceBranch:    "bugfix/some",
+			DestBranch:      "develop",
+			Bump:            "auto",
+			ExpectedMethod:  "build",
+			ExpectedVersion: "patch",
+		},
+		"source branch feature, dest branch develop and auto bump": {
+			SourceBranch:    "feature/some",
+			DestBranch:      "develop",
+			Bump:            "auto",
+			ExpectedMethod:  "build",
+			ExpectedVersion: "minor",
+		},
+		"source branch major, dest branch develop and auto bump": {
+			SourceBranch:    "major/some",
+			DestBranch:      "develop",
+			Bump:            "auto",
+			ExpectedMethod:  "build",
+			ExpectedVersion: "major",
+		},
+		"source branch doc, dest branch develop and auto bump": {
+			SourceBranch:    "doc/some",
+			DestBranch:      "develop",
+			Bump:            "auto",
+			ExpectedMethod:  "build",
+			ExpectedVersion: "build",
+		},
+		"source branch hotfix, dest branch master and auto bump": {
+			SourceBranch:   "hotfix/some",
+			DestBranch:     "master",
+			Bump:           "auto",
+			ExpectedMethod: "hotfix",
+		},
+		"source branch ignore": {
+			SourceBranch:    "ignore/some",
+			ExcludePattern:  regex.MustCompile(`(?i)^ignore/.+`),
+			ExpectedMethod:  "",
+			ExpectedVersion: "",
+		},
+		"source branch ignore, no exclude pattern": {
+			SourceBranch:    "ignore/some",
+			ExpectedMethod:  "",
+			ExpectedVersion: "",
+		},
+		"source branch ignore, no exclude pattern and auto bump": {
+			SourceBranch:    "ignore/some",
+			Bump:            "auto",
+			ExpectedMethod:  "build",
+			ExpectedVersion: "",
+		},
+		"source branch develop, dest branch master and auto bump": {
+			SourceBranch:   "develop",
+			DestBranch:     "master",
+			Bump:           "auto",
+			ExpectedMethod: "final",
+		},
+		"not a valid source branch prefix and auto bump": {
+			SourceBranch:   "some-branch",
+			Bump:           "auto",
+			ExpectedMethod: "build",
+		},
+		"patch bump": {
+			Bump:           "patch",
+			ExpectedMethod: "patch",
+		},
+		"minor bump": {
+			Bump:           "minor",
+			ExpectedMethod: "minor",
+		},
+		"major bump": {
+			Bump:           "major",
+			ExpectedMethod: "major",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				Bump:              test.Bump,
+				BranchingModel:    "git-flow",
+				DevelopBranchName: "develop",
+				MainBranchName:    "master",
+				PatchPattern:      regex.MustCompile(`(?i)^bugfix/.+`),
+				MinorPattern:      regex.MustCompile(`(?i)^feature/.+`),
+				MajorPattern:      regex.MustCompile(`(?i)^major/.+`),
+				BuildPattern:      regex.MustCompile(`(?i)^(doc(s)?|misc)/.+`),
+				HotfixPattern:     regex.MustCompile(`(?i)^hotfix/.+`),
+				ExcludePattern:    test.ExcludePattern,
+			})
+			require.NoError(t, err)
+
+			method, version := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+				SourceBranch: test.SourceBranch,
+				DestBranch:   test.DestBranch,
+			})
+
+			assert.Equal(t, test.ExpectedMethod, method)
+			assert.Equal(t, test.ExpectedVersion, version)
+		})
+	}
+}
+
+func TestDetermineBumpStrategy_Gitflow_Commits(t *testing.T) {
+	tests := map[string]struct {
+		SourceBranch    string
+		DestBranch      string
+		Commits         []git.Commit
+		ExpectedMethod  string
+		ExpectedVersion string
+	}{
+		"breaking change footer into develop": {
+			SourceBranch: "some-branch",
+			DestBranch:   "develop",
+			Commits: []git.Commit{
+				{Message: "fix: some bug"},
+				{Message: "refactor: drop old api\n\nBREAKING CHANGE: old api removed"},
+			},
+			ExpectedMethod:  "build",
+			ExpectedVersion: "major",
+		},
+		"feature commit into develop": {
+			SourceBranch: "bugfix/some",
+			DestBranch:   "develop",
+			Commits: []git.Commit{
+				{Message: "fix: some bug"},
+				{Message: "feat(api): new endpoint"},
+			},
+			ExpectedMethod:  "build",
+			ExpectedVersion: "minor",
+		},
+		"no conventional commits falls back to branch pattern": {
+			SourceBranch: "bugfix/some",
+			DestBranch:   "develop",
+			Commits: []git.Commit{
+				{Message: "update readme"},
+			},
+			ExpectedMethod:  "build",
+			ExpectedVersion: "patch",
+		},
+		"commits are ignored into master": {
+			SourceBranch: "develop",
+			DestBranch:   "master",
+			Commits: []git.Commit{
+				{Message: "feat!: new api"},
+			},
+			ExpectedMethod: "final",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				Bump:              "auto",
+				BumpSource:        "commits",
+				BranchingModel:    "git-flow",
+				MainBranchName:    "master",
+				DevelopBranchName: "develop",
+				PatchPattern:      regex.MustCompile(`(?i)^bugfix/.+`),
+				MinorPattern:      regex.MustCompile(`(?i)^feature/.+`),
+				MajorPattern:      regex.MustCompile(`(?i)^major/.+`),
+				BuildPattern:      regex.MustCompile(`(?i)^(doc(s)?|misc)/.+`),
+				HotfixPattern:     regex.MustCompile(`(?i)^hotfix/.+`),
+			})
+			require.NoError(t, err)
+
+			method, version := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+				SourceBranch: test.SourceBranch,
+				DestBranch:   test.DestBranch,
+				Commits:      test.Commits,
+			})
+
+			assert.Equal(t, test.ExpectedMethod, method)
+			assert.Equal(t, test.ExpectedVersion, version)
+		})
+	}
+}
+
+func TestDetermineBumpStrategy_Gitflow_Rules(t *testing.T) {
+	tests := map[string]struct {
+		SourceBranch    string
+		DestBranch      string
+		ExpectedMethod  string
+		ExpectedVersion string
+	}{
+		"rule without bump": {
+			SourceBranch: "chore/some",
+			DestBranch:   "master",
+		},
+		"rule overrides built-in rule": {
+			SourceBranch:    "deps/some",
+			DestBranch:      "develop",
+			ExpectedMethod:  "build",
+			ExpectedVersion: "build",
+		},
+		"rule matching any dest branch": {
+			SourceBranch:    "perf/some",
+			DestBranch:      "develop",
+			ExpectedMethod:  "build",
+			ExpectedVersion: "patch",
+		},
+		"falls back to built-in rules": {
+			SourceBranch:    "feature/some",
+			DestBranch:      "develop",
+			ExpectedMethod:  "build",
+			ExpectedVersion: "minor",
+		},
+		"falls back to develop branch into master": {
+			SourceBranch:   "develop",
+			DestBranch:     "master",
+			ExpectedMethod: "final",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				Bump:              "auto",
+				BranchingModel:    "git-flow",
+				MainBranchName:    "master",
+				DevelopBranchName: "develop",
+				PatchPattern:      regex.MustCompile(`(?i)^bugfix/.+`),
+				MinorPattern:      regex.MustCompile(`(?i)^feature/.+`),
+				MajorPattern:      regex.MustCompile(`(?i)^major/.+`),
+				BuildPattern:      regex.MustCompile(`(?i)^(doc(s)?|misc)/.+`),
+				HotfixPattern:     regex.MustCompile(`(?i)^hotfix/.+`),
+				Rules: strategy.Rules{
+					{Source: regex.MustCompile(`^chore/.+`), Dest: regex.MustCompile(`^master$`)},
+					{Source: regex.MustCompile(`^(deps|bugfix)/.+`), Dest: regex.MustCompile(`^develop$`), Method: "build", Version: "build"},
+					{Source: regex.MustCompile(`^perf/.+`), Method: "build", Version: "patch"},
+				},
+			})
+			require.NoError(t, err)
+
+			method, version := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+				SourceBranch: test.SourceBranch,
+				DestBranch:   test.DestBranch,
+			})
+
+			assert.Equal(t, test.ExpectedMethod, method)
+			assert.Equal(t, test.ExpectedVersion, version)
+		})
+	}
+}
+
+func TestDetermineBumpStrategy_Gitflow_Labels(t *testing.T) {
+	tests := map[string]struct {
+		SourceBranch    string
+		DestBranch      string
+		Labels          []string
+		ExpectedMethod  string
+		ExpectedVersion string
+		ExpectedNotes   []string
+	}{
+		"label overrides branch pattern into develop": {
+			SourceBranch:    "bugfix/some",
+			DestBranch:      "develop",
+			Labels:          []string{"docs", "semver:major"},
+			ExpectedMethod:  "build",
+			ExpectedVersion: "major",
+		},
+		"highest label wins": {
+			SourceBranch:    "some-branch",
+			DestBranch:      "develop",
+			Labels:          []string{"semver:patch", "semver:minor"},
+			ExpectedMethod:  "build",
+			ExpectedVersion: "minor",
+		},
+		"skip label wins": {
+			SourceBranch: "feature/some",
+			DestBranch:   "develop",
+			Labels:       []string{"semver:major", "semver:skip"},
+		},
+		"skip label into master": {
+			SourceBranch: "develop",
+			DestBranch:   "master",
+			Labels:       []string{"semver:skip"},
+		},
+		"labels are ignored into master": {
+			SourceBranch:   "develop",
+			DestBranch:     "master",
+			Labels:         []string{"semver:major"},
+			ExpectedMethod: "final",
+			ExpectedNotes:  []string{"pull request label semver:major is ignored into master branch"},
+		},
+		"unmapped labels fall back to branch pattern": {
+			SourceBranch:    "feature/some",
+			DestBranch:      "develop",
+			Labels:          []string{"docs"},
+			ExpectedMethod:  "build",
+			ExpectedVersion: "minor",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				Bump:              "auto",
+				BranchingModel:    "git-flow",
+				MainBranchName:    "master",
+				DevelopBranchName: "develop",
+				PatchPattern:      regex.MustCompile(`(?i)^bugfix/.+`),
+				MinorPattern:      regex.MustCompile(`(?i)^feature/.+`),
+				MajorPattern:      regex.MustCompile(`(?i)^major/.+`),
+				BuildPattern:      regex.MustCompile(`(?i)^(doc(s)?|misc)/.+`),
+				HotfixPattern:     regex.MustCompile(`(?i)^hotfix/.+`),
+				LabelBumps: map[string]string{
+					"semver:major": "major",
+					"semver:minor": "minor",
+					"semver:patch": "patch",
+					"semver:skip":  "skip",
+				},
+			})
+			require.NoError(t, err)
+
+			tr := &trace.Trace{}
+
+			method, version := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+				SourceBranch: test.SourceBranch,
+				DestBranch:   test.DestBranch,
+				Labels:       test.Labels,
+				Trace:        tr,
+			})
+
+			assert.Equal(t, test.ExpectedMethod, method)
+			assert.Equal(t, test.ExpectedVersion, version)
+			assert.Equal(t, test.ExpectedNotes, tr.Notes)
+		})
+	}
+}
+
+func TestTag_Gitflow(t *testing.T) {
+	tests := map[string]struct {
+		Method      string
+		AncestorTag string
+		Tag         *semver.Version
+		Version     string
+		Expected    strategy.Result
+	}{
+		"build": {
+			Method:      "build",
+			Tag:         newSemVerPtr(t, "1.2.3-alpha.2"),
+			AncestorTag: "v1.2.3-alpha.1",
+			Expected: strategy.Result{
+				AncestorTag:  "v1.2.3-alpha.1",
+				SemverTag:    "v1.2.3-alpha.3",
+				IsPrerelease: true,
+			},
+		},
+		"major with pre release tag": {
+			Method:      "build",
+			Version:     "major",
+			Tag:         newSemVerPtr(t, "1.2.3-alpha.1"),
+			AncestorTag: "v1.2.2-alpha.1",
+			Expected: strategy.Result{
+				AncestorTag:  "v1.2.2-alpha.1",
+				SemverTag:    "v2.0.0-alpha.1",
+				IsPrerelease: true,
+			},
+		},
+		"major without pre release tag": {
+			Method:      "major",
+			Tag:         newSemVerPtr(t, "1.2.3"),
+			AncestorTag: "v1.2.2",
+			Expected: strategy.Result{
+				AncestorTag:  "v1.2.2",
+				SemverTag:    "v2.0.0",
+				IsPrerelease: false,
+			},
+		},
+		"minor with pre release tag": {
+			Method:      "build",
+			Version:     "minor",
+			Tag:         newSemVerPtr(t, "1.2.3-alpha.1"),
+			AncestorTag: "v1.2.2-alpha.1",
+			Expected: strategy.Result{
+				AncestorTag:  "v1.2.2-alpha.1",
+				SemverTag:    "v1.3.0-alpha.1",
+				IsPrerelease: true,
+			},
+		},
+		"minor without pre release tag": {
+			Method:      "minor",
+			Tag:         newSemVerPtr(t, "1.2.3"),
+			AncestorTag: "v1.2.2",
+			Expected: strategy.Result{
+				AncestorTag:  "v1.2.2",
+				SemverTag:    "v1.3.0",
+				IsPrerelease: false,
+			},
+		},
+		"patch with pre release tag": {
+			Method:      "build",
+			Version:     "patch",
+			Tag:         newSemVerPtr(t, "1.2.3-alpha.1"),
+			AncestorTag: "v1.2.2-alpha.1",
+			Expected: strategy.Result{
+				AncestorTag:  "v1.2.2-alpha.1",
+				SemverTag:    "v1.2.4-alpha.1",
+				IsPrerelease: true,
+			},
+		},
+		"patch without pre release tag": {
+			Method:      "patch",
+			Tag:         newSemVerPtr(t, "1.2.3"),
+			AncestorTag: "v1.2.2",
+			Expected: strategy.Result{
+				AncestorTag:  "v1.2.2",
+				SemverTag:    "v1.2.4",
+				IsPrerelease: false,
+			},
+		},
+		"final version": {
+			Method:      "final",
+			Tag:         newSemVerPtr(t, "1.2.3-alpha.1"),
+			AncestorTag: "v1.2.2-alpha.1",
+			Expected: strategy.Result{
+				AncestorTag:  "v1.2.2-alpha.1",
+				SemverTag:    "v1.2.3",
+				IsPrerelease: false,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gf := strategy.GitFlow{}
+
+			gc := initGitClientMock(
+				t, "", test.AncestorTag, "", "", "",
+			)
+
+			result, err := gf.Tag(strategy.TagParams{
+				DestBranch:   "not-used",
+				Prefix:       "v",
+				PrereleaseID: "alpha",
+				Method:       test.Method,
+				Tag:          test.Tag,
+				Version:      test.Version,
+			}, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result)
+		})
+	}
+}
+
+func TestTag_Gitflow_PrereleaseChannels(t *testing.T) {
+	tests := map[string]struct {
+		PrereleaseID    string
+		Tag             *semver.Version
+		Version         string
+		ExpectedInclude string
+		Expected        strategy.Result
+	}{
+		"same channel continues counter": {
+			PrereleaseID:    "beta",
+			Tag:             newSemVerPtr(t, "1.3.0-beta.2"),
+			ExpectedInclude: "v[0-9]*-beta*",
+			Expected: strategy.Result{
+				AncestorTag:  "v1.2.0",
+				SemverTag:    "v1.3.0-beta.3",
+				IsPrerelease: true,
+			},
+		},
+		"promotion starts counter over": {
+			PrereleaseID:    "beta",
+			Tag:             newSemVerPtr(t, "1.3.0-alpha.5"),
+			ExpectedInclude: "v[0-9]*-beta*",
+			Expected: strategy.Result{
+				AncestorTag:  "v1.2.0",
+				SemverTag:    "v1.3.0-beta.1",
+				IsPrerelease: true,
+			},
+		},
+		"promotion to release candidate": {
+			PrereleaseID:    "rc",
+			Tag:             newSemVerPtr(t, "1.3.0-beta.3"),
+			ExpectedInclude: "v[0-9]*-rc*",
+			Expected: strategy.Result{
+				AncestorTag:  "v1.2.0",
+				SemverTag:    "v1.3.0-rc.1",
+				IsPrerelease: true,
+			},
+		},
+		"bump starts counter over": {
+			PrereleaseID:    "alpha",
+			Tag:             newSemVerPtr(t, "1.3.0-alpha.5"),
+			Version:         "minor",
+			ExpectedInclude: "v[0-9]*-alpha*",
+			Expected: strategy.Result{
+				AncestorTag:  "v1.2.0",
+				SemverTag:    "v1.4.0-alpha.1",
+				IsPrerelease: true,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gf := strategy.GitFlow{}
+
+			gc := initGitClientMock(t, "", "", "", "", "")
+			gc.AncestorTagFn = func(include, exclude, branch string) string {
+				assert.Equal(t, test.ExpectedInclude, include)
+				return "v1.2.0"
+			}
+
+			tr := &trace.Trace{}
+
+			result, err := gf.Tag(strategy.TagParams{
+				DestBranch:   "release/1.3",
+				Prefix:       "v",
+				PrereleaseID: test.PrereleaseID,
+				Method:       "build",
+				Tag:          test.Tag,
+				Version:      test.Version,
+				Trace:        tr,
+			}, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result)
+		})
+	}
+}
+
+func TestTag_Gitflow_FinalExcludesPrereleases(t *testing.T) {
+	gf := strategy.GitFlow{}
+
+	gc := initGitClientMock(t, "", "", "", "", "")
+	gc.AncestorTagFn = func(include, exclude, branch string) string {
+		assert.Equal(t, "v[0-9]*", include)
+		assert.Equal(t, "v[0-9]*-*", exclude)
+
+		return "v1.2.0"
+	}
+
+	result, err := gf.Tag(strategy.TagParams{
+		DestBranch:   "master",
+		Prefix:       "v",
+		PrereleaseID: "pre",
+		Method:       "final",
+		Tag:          newSemVerPtr(t, "1.3.0-rc.2"),
+	}, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.3.0", result.SemverTag)
+}
+
+func TestTag_Gitflow_InitialDevelopment(t *testing.T) {
+	tests := map[string]struct {
+		Method   string
+		Tag      *semver.Version
+		Version  string
+		Expected strategy.Result
+	}{
+		"major into develop downgraded to minor": {
+			Method:  "build",
+			Tag:     newSemVerPtr(t, "0.4.2"),
+			Version: "major",
+			Expected: strategy.Result{
+				AncestorTag:  "v0.4.2",
+				SemverTag:    "v0.5.0-alpha.1",
+				IsPrerelease: true,
+			},
+		},
+		"minor into develop downgraded to patch": {
+			Method:  "build",
+			Tag:     newSemVerPtr(t, "0.4.2"),
+			Version: "minor",
+			Expected: strategy.Result{
+				AncestorTag:  "v0.4.2",
+				SemverTag:    "v0.4.3-alpha.1",
+				IsPrerelease: true,
+			},
+		},
+		"major bump downgraded to minor": {
+			Method: "major",
+			Tag:    newSemVerPtr(t, "0.4.2"),
+			Expected: strategy.Result{
+				AncestorTag: "v0.4.2",
+				SemverTag:   "v0.5.0",
+			},
+		},
+		"major into develop after initial development": {
+			Method:  "build",
+			Tag:     newSemVerPtr(t, "1.4.2"),
+			Version: "major",
+			Expected: strategy.Result{
+				AncestorTag:  "v0.4.2",
+				SemverTag:    "v2.0.0-alpha.1",
+				IsPrerelease: true,
+			},
+		},
+		"graduate prerelease": {
+			Method: "graduate",
+			Tag:    newSemVerPtr(t, "0.5.0-alpha.3"),
+			Expected: strategy.Result{
+				AncestorTag: "v0.4.2",
+				SemverTag:   "v1.0.0",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				BranchingModel:     "git-flow",
+				InitialDevelopment: true,
+			})
+			require.NoError(t, err)
+
+			gc := initGitClientMock(t, "", "", "", "", "")
+			gc.AncestorTagFn = func(include, exclude, branch string) string {
+				return "v0.4.2"
+			}
+
+			result, err := branchingStrategy.Tag(strategy.TagParams{
+				DestBranch:   "develop",
+				Prefix:       "v",
+				PrereleaseID: "alpha",
+				Method:       test.Method,
+				Tag:          test.Tag,
+				Version:      test.Version,
+			}, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result)
+		})
+	}
+}
+
+func TestGitflow_Trace(t *testing.T) {
+	branchingStrategy, err := strategy.New(strategy.Configuration{
+		Bump:              "auto",
+		BranchingModel:    "git-flow",
+		DevelopBranchName: "develop",
+		MainBranchName:    "master",
+		PatchPattern:      regex.MustCompile(`(?i)^bugfix/.+`),
+		MinorPattern:      regex.MustCompile(`(?i)^feature/.+`),
+		MajorPattern:      regex.MustCompile(`(?i)^major/.+`),
+		BuildPattern:      regex.MustCompile(`(?i)^(doc(s)?|misc)/.+`),
+		HotfixPattern:     regex.MustCompile(`(?i)^hotfix/.+`),
+	})
+	require.NoError(t, err)
+
+	tr := &trace.Trace{}
+
+	method, version := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+		SourceBranch: "feature/some",
+		DestBranch:   "develop",
+		Trace:        tr,
+	})
+
+	assert.Equal(t, "source branch matches minor pattern into develop branch", tr.Decision)
+	assert.Equal(t, "(?i)^feature/.+", tr.Pattern)
+
+	gc := initGitClientMock(t, "", "v1.2.2", "", "", "")
+
+	result, err := branchingStrategy.Tag(strategy.TagParams{
+		DestBranch:   "develop",
+		Method:       method,
+		Version:      version,
+		Prefix:       "v",
+		PrereleaseID: "alpha",
+		Tag:          newSemVerPtr(t, "1.2.3-alpha.4"),
+		Trace:        tr,
+	}, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.3.0-alpha.1", result.SemverTag)
+	assert.Equal(t, []trace.Increment{
+		{Description: "increment minor", From: "1.2.3-alpha.4", To: "1.3.0-alpha.4"},
+		{Description: "set prerelease build number", From: "1.3.0-alpha.4", To: "1.3.0-alpha.1"},
+	}, tr.Increments)
+}
@@ -0,0 +1,213 @@
+package strategy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/apex/log"
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/regex"
+)
+
+// previewID is the prerelease identifier of pull request previews.
+const previewID = "pr"
+
+// GitHubFlow implements the GitHub Flow strategy. Every merge into the main
+// branch is released, and unmerged pull requests get preview versions.
+type GitHubFlow struct {
+	bump           string
+	bumpSource     string
+	branchName     string
+	excludePattern regex.Regex
+	// labelBumps maps pull request labels to major, minor, patch or skip.
+	labelBumps map[string]string
+	// rules are the configured rules followed by the built-in ones.
+	rules Rules
+	// initialDevelopment downgrades major to minor and minor to patch while
+	// the major version is zero.
+	initialDevelopment bool
+}
+
+// NewGitHubFlow returns the github-flow strategy, registered as "github-flow".
+func NewGitHubFlow(config Configuration) (Strategy, error) {
+	return &GitHubFlow{
+		bump:               config.Bump,
+		bumpSource:         config.BumpSource,
+		branchName:         config.MainBranchName,
+		excludePattern:     config.ExcludePattern,
+		labelBumps:         config.LabelBumps,
+		rules:              append(append(Rules{}, config.Rules...), gitHubFlowRules(config)...),
+		initialDevelopment: config.InitialDevelopment,
+	}, nil
+}
+
+// gitHubFlowRules returns the built-in rules of github-flow model. They match
+// any dest branch, as only merges into the main branch are evaluated.
+func gitHubFlowRules(config Configuration) Rules {
+	return Rules{
+		// bugfix into main branch
+		{
+			Source:      config.PatchPattern,
+			Method:      "patch",
+			Description: "source branch matches patch pattern into main branch",
+		},
+		// feature into main branch
+		{
+			Source:      config.MinorPattern,
+			Method:      "minor",
+			Description: "source branch matches minor pattern into main branch",
+		},
+		// major into main branch
+		{
+			Source:      config.MajorPattern,
+			Method:      "major",
+			Description: "source branch matches major pattern into main branch",
+		},
+	}.withSources()
+}
+
+// DetermineBumpStrategy determines the strategy for semver to bump product version.
+func (g *GitHubFlow) DetermineBumpStrategy(params BumpParams) (string, string) {
+	sourceBranch, destBranch := params.SourceBranch, params.DestBranch
+
+	// if source branch is excluded, do not bump
+	if g.excludePattern != nil && g.excludePattern.MatchString(sourceBranch) {
+		params.Trace.Decide("source branch matches exclude pattern", g.excludePattern)
+		return "", ""
+	}
+
+	labelBump, label := labelsBump(params.Labels, g.labelBumps)
+
+	// if a pull request label skips the bump, do not bump
+	if labelBump == "skip" {
+		params.Trace.Decide(fmt.Sprintf("pull request label %s skips the bump", label), nil)
+		return "", ""
+	}
+
+	// if bump is not auto, return it
+	if g.bump != "auto" {
+		noteIgnoredLabel(params.Trace, label, fmt.Sprintf("as bump is %s", g.bump))
+
+		params.Trace.Decide(fmt.Sprintf("bump is %s", g.bump), nil)
+		return g.bump, ""
+	}
+
+	// only merges into main branch are released
+	if destBranch != g.branchName {
+		params.Trace.Decide("dest branch is not main branch", nil)
+		return "", ""
+	}
+
+	// pull request labels take precedence over commits and branch patterns
+	if labelBump != "" {
+		params.Trace.Decide(fmt.Sprintf("pull request label %s requires %s into main branch", label, labelBump), nil)
+		return labelBump, ""
+	}
+
+	// conventional commits into main branch, falls back to branch patterns
+	if g.bumpSource == "commits" {
+		if version := commitsBump(params.Commits); version != "" {
+			params.Trace.Decide(fmt.Sprintf("conventional commits require %s into main branch", version), nil)
+			return version, ""
+		}
+	}
+
+	if method, version, ok := g.rules.Decide(params); ok {
+		return method, version
+	}
+
+	// every merge into main branch is released
+	params.Trace.Decide("source branch matches no pattern into main branch", nil)
+
+	return "patch", ""
+}
+
+// Tag implements the Strategy interface.
+func (g *GitHubFlow) Tag(params TagParams, gc git.Git) (Result, error) {
+	if g.initialDevelopment {
+		params.Method = initialDevelopmentLevel(params.Method, params.Tag, params.Trace)
+	}
+
+	before := params.Tag.String()
+
+	switch params.Method {
+	case "major":
+		log.Debug("incrementing major")
+
+		if err := params.Tag.IncrementMajor(); err != nil {
+			return Result{}, fmt.Errorf("failed to increment major version: %s", err)
+		}
+
+		params.Trace.Increment("increment major", before, params.Tag.FinalizeVersion())
+	case "minor":
+		log.Debug("incrementing minor")
+
+		if err := params.Tag.IncrementMinor(); err != nil {
+			return Result{}, fmt.Errorf("failed to increment minor version: %s", err)
+		}
+
+		params.Trace.Increment("increment minor", before, params.Tag.FinalizeVersion())
+	case "patch":
+		log.Debug("incrementing patch")
+
+		if err := params.Tag.IncrementPatch(); err != nil {
+			return Result{}, fmt.Errorf("failed to increment patch version: %s", err)
+		}
+
+		params.Trace.Increment("increment patch", before, params.Tag.FinalizeVersion())
+	case "graduate":
+		if err := graduate(params); err != nil {
+			return Result{}, err
+		}
+	}
+
+	version := params.Tag.FinalizeVersion()
+
+	if params.PullRequest == 0 {
+		return Result{
+			SemverTag:    params.Prefix + version,
+			IsPrerelease: false,
+		}, nil
+	}
+
+	// previews of the same pull request and version are numbered in sequence
+	previewPrefix := fmt.Sprintf("%s%s-%s.%d.", params.Prefix, version, previewID, params.PullRequest)
+
+	tags, err := gc.Tags(previewPrefix + "*")
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to list preview tags: %s", err)
+	}
+
+	var previewNumber int
+
+	for _, tag := range tags {
+		n, err := strconv.Atoi(strings.TrimPrefix(tag, previewPrefix))
+		if err == nil && n > previewNumber {
+			previewNumber = n
+		}
+	}
+
+	preview := fmt.Sprintf("%s-%s.%d.%d", version, previewID, params.PullRequest, previewNumber+1)
+
+	params.Trace.Increment(fmt.Sprintf("set pull request #%d preview number", params.PullRequest), version, preview)
+
+	return Result{
+		SemverTag:    params.Prefix + preview,
+		IsPrerelease: true,
+	}, nil
+}
+
+// Features implements the Describer interface.
+func (*GitHubFlow) Features() Features {
+	return Features{
+		Methods:           []string{"major", "minor", "patch", "graduate"},
+		PreviewTagPattern: "*-" + previewID + ".*",
+		Rules:             true,
+	}
+}
+
+// Name returns the name of the strategy.
+func (GitHubFlow) Name() string {
+	return "github-flow"
+}
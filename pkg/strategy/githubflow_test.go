@@ -0,0 +1,264 @@
+package strategy_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/gandarez/semver-action/pkg/regex"
+	"github.com/gandarez/semver-action/pkg/strategy"
+	"github.com/gandarez/semver-action/pkg/trace"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetermineBumpStrategy_GitHubFlow(t *testing.T) {
+	tests := map[string]struct {
+		SourceBranch   string
+		DestBranch     string
+		Bump           string
+		ExcludePattern regex.Regex
+		ExpectedMethod string
+	}{
+		"source branch bugfix, dest branch master and auto bump": {
+			SourceBranch:   "bugfix/some",
+			DestBranch:     "master",
+			Bump:           "auto",
+			ExpectedMethod: "patch",
+		},
+		"source branch feature, dest branch master and auto bump": {
+			SourceBranch:   "feature/some",
+			DestBranch:     "master",
+			Bump:           "auto",
+			ExpectedMethod: "minor",
+		},
+		"source branch major, dest branch master and auto bump": {
+			SourceBranch:   "major/some",
+			DestBranch:     "master",
+			Bump:           "auto",
+			ExpectedMethod: "major",
+		},
+		"not a valid source branch prefix, dest branch master and auto bump": {
+			SourceBranch:   "some-branch",
+			DestBranch:     "master",
+			Bump:           "auto",
+			ExpectedMethod: "patch",
+		},
+		"source branch feature, dest branch other and auto bump": {
+			SourceBranch:   "feature/some",
+			DestBranch:     "other",
+			Bump:           "auto",
+			ExpectedMethod: "",
+		},
+		"source branch ignore": {
+			SourceBranch:   "ignore/some",
+			DestBranch:     "master",
+			Bump:           "auto",
+			ExcludePattern: regex.MustCompile(`(?i)^ignore/.+`),
+			ExpectedMethod: "",
+		},
+		"minor bump": {
+			DestBranch:     "other",
+			Bump:           "minor",
+			ExpectedMethod: "minor",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				Bump:           test.Bump,
+				BranchingModel: "github-flow",
+				MainBranchName: "master",
+				PatchPattern:   regex.MustCompile(`(?i)^bugfix/.+`),
+				MinorPattern:   regex.MustCompile(`(?i)^feature/.+`),
+				MajorPattern:   regex.MustCompile(`(?i)^major/.+`),
+				BuildPattern:   regex.MustCompile(`(?i)^(doc(s)?|misc)/.+`),
+				ExcludePattern: test.ExcludePattern,
+			})
+			require.NoError(t, err)
+
+			method, version := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+				SourceBranch: test.SourceBranch,
+				DestBranch:   test.DestBranch,
+			})
+
+			assert.Equal(t, test.ExpectedMethod, method)
+			assert.Empty(t, version)
+		})
+	}
+}
+
+func TestTag_GitHubFlow(t *testing.T) {
+	tests := map[string]struct {
+		Method          string
+		Tag             *semver.Version
+		PullRequest     int
+		PreviewTags     []string
+		ExpectedPattern string
+		Expected        strategy.Result
+	}{
+		"major": {
+			Method: "major",
+			Tag:    newSemVerPtr(t, "1.2.3"),
+			Expected: strategy.Result{
+				SemverTag:    "v2.0.0",
+				IsPrerelease: false,
+			},
+		},
+		"minor": {
+			Method: "minor",
+			Tag:    newSemVerPtr(t, "1.2.3"),
+			Expected: strategy.Result{
+				SemverTag:    "v1.3.0",
+				IsPrerelease: false,
+			},
+		},
+		"patch": {
+			Method: "patch",
+			Tag:    newSemVerPtr(t, "1.2.3"),
+			Expected: strategy.Result{
+				SemverTag:    "v1.2.4",
+				IsPrerelease: false,
+			},
+		},
+		"first preview": {
+			Method:          "minor",
+			Tag:             newSemVerPtr(t, "1.2.3"),
+			PullRequest:     12,
+			ExpectedPattern: "v1.3.0-pr.12.*",
+			Expected: strategy.Result{
+				SemverTag:    "v1.3.0-pr.12.1",
+				IsPrerelease: true,
+			},
+		},
+		"next preview": {
+			Method:          "patch",
+			Tag:             newSemVerPtr(t, "1.2.3"),
+			PullRequest:     12,
+			PreviewTags:     []string{"v1.2.4-pr.12.1", "v1.2.4-pr.12.10", "v1.2.4-pr.12.9", "v1.2.4-pr.12.x"},
+			ExpectedPattern: "v1.2.4-pr.12.*",
+			Expected: strategy.Result{
+				SemverTag:    "v1.2.4-pr.12.11",
+				IsPrerelease: true,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			gc := &gitClientMock{
+				TagsFn: func(pattern string) ([]string, error) {
+					assert.Equal(t, test.ExpectedPattern, pattern)
+					return test.PreviewTags, nil
+				},
+			}
+
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				BranchingModel: "github-flow",
+			})
+			require.NoError(t, err)
+
+			result, err := branchingStrategy.Tag(strategy.TagParams{
+				Method:      test.Method,
+				Prefix:      "v",
+				Tag:         test.Tag,
+				PullRequest: test.PullRequest,
+			}, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result)
+		})
+	}
+}
+
+func TestTag_GitHubFlow_TagsErr(t *testing.T) {
+	gc := &gitClientMock{
+		TagsFn: func(pattern string) ([]string, error) {
+			return nil, errors.New("error")
+		},
+	}
+
+	branchingStrategy, err := strategy.New(strategy.Configuration{
+		BranchingModel: "github-flow",
+	})
+	require.NoError(t, err)
+
+	_, err = branchingStrategy.Tag(strategy.TagParams{
+		Method:      "minor",
+		Prefix:      "v",
+		Tag:         newSemVerPtr(t, "1.2.3"),
+		PullRequest: 12,
+	}, gc)
+
+	assert.EqualError(t, err, "failed to list preview tags: error")
+}
+
+func TestTag_GitHubFlow_InitialDevelopment(t *testing.T) {
+	branchingStrategy, err := strategy.New(strategy.Configuration{
+		BranchingModel:     "github-flow",
+		InitialDevelopment: true,
+	})
+	require.NoError(t, err)
+
+	tr := &trace.Trace{}
+
+	result, err := branchingStrategy.Tag(strategy.TagParams{
+		Prefix: "v",
+		Method: "major",
+		Tag:    newSemVerPtr(t, "0.4.2"),
+		Trace:  tr,
+	}, &gitClientMock{})
+	require.NoError(t, err)
+
+	assert.Equal(t, "v0.5.0", result.SemverTag)
+	assert.Equal(t, []string{"initial development, major downgraded to minor"}, tr.Notes)
+	assert.Equal(t, []trace.Increment{
+		{Description: "increment minor", From: "0.4.2", To: "0.5.0"},
+	}, tr.Increments)
+}
+
+func TestGitHubFlow_Trace(t *testing.T) {
+	branchingStrategy, err := strategy.New(strategy.Configuration{
+		Bump:           "auto",
+		BranchingModel: "github-flow",
+		MainBranchName: "master",
+		PatchPattern:   regex.MustCompile(`(?i)^bugfix/.+`),
+		MinorPattern:   regex.MustCompile(`(?i)^feature/.+`),
+		MajorPattern:   regex.MustCompile(`(?i)^major/.+`),
+	})
+	require.NoError(t, err)
+
+	tr := &trace.Trace{}
+
+	method, version := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+		SourceBranch: "some-branch",
+		DestBranch:   "master",
+		Trace:        tr,
+	})
+
+	assert.Equal(t, "source branch matches no pattern into main branch", tr.Decision)
+
+	gc := &gitClientMock{
+		TagsFn: func(pattern string) ([]string, error) {
+			return nil, nil
+		},
+	}
+
+	result, err := branchingStrategy.Tag(strategy.TagParams{
+		Method:      method,
+		Version:     version,
+		Prefix:      "v",
+		Tag:         newSemVerPtr(t, "1.2.3"),
+		PullRequest: 7,
+		Trace:       tr,
+	}, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.2.4-pr.7.1", result.SemverTag)
+	assert.Equal(t, []trace.Increment{
+		{Description: "increment patch", From: "1.2.3", To: "1.2.4"},
+		{Description: "set pull request #7 preview number", From: "1.2.4", To: "1.2.4-pr.7.1"},
+	}, tr.Increments)
+}
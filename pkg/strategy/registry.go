@@ -0,0 +1,62 @@
+package strategy
+
+import "sync"
+
+// Factory creates a strategy from the configuration.
+type Factory func(config Configuration) (Strategy, error)
+
+// nolint: gochecknoglobals
+var (
+	registryMu sync.RWMutex
+	factories  = make(map[string]Factory)
+	// names keeps the registration order, so the built-in models come first.
+	names []string
+)
+
+// nolint: gochecknoinits
+func init() {
+	Register("git-flow", NewGitFlow)
+	Register("trunk-based", NewTrunkBased)
+	Register("github-flow", NewGitHubFlow)
+	Register("calver", NewCalVer)
+}
+
+// Register makes a strategy available by name as a branching model. It's meant
+// to be called from an init function and panics if name is empty, factory is nil
+// or name is already registered.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if name == "" {
+		panic("strategy: register with empty name")
+	}
+
+	if factory == nil {
+		panic("strategy: register nil factory for " + name)
+	}
+
+	if _, ok := factories[name]; ok {
+		panic("strategy: register called twice for " + name)
+	}
+
+	factories[name] = factory
+	names = append(names, name)
+}
+
+// Names returns the registered branching models in registration order.
+func Names() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return append([]string(nil), names...)
+}
+
+func lookup(name string) (Factory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	factory, ok := factories[name]
+
+	return factory, ok
+}
@@ -0,0 +1,88 @@
+package strategy_test
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/strategy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fixedStrategy struct {
+	method string
+}
+
+func (s fixedStrategy) DetermineBumpStrategy(strategy.BumpParams) (string, string) {
+	return s.method, ""
+}
+
+func (fixedStrategy) Tag(params strategy.TagParams, _ git.Git) (strategy.Result, error) {
+	return strategy.Result{SemverTag: params.Prefix + params.Tag.String()}, nil
+}
+
+func (fixedStrategy) Name() string {
+	return "fixed"
+}
+
+// nolint: gochecknoglobals
+var registerOnce sync.Once
+
+func TestRegister(t *testing.T) {
+	registerOnce.Do(func() {
+		strategy.Register("registry-test", func(config strategy.Configuration) (strategy.Strategy, error) {
+			return fixedStrategy{method: config.Options["method"]}, nil
+		})
+	})
+
+	assert.Equal(t, []string{"git-flow", "trunk-based", "github-flow", "calver", "registry-test"}, strategy.Names())
+
+	branchingStrategy, err := strategy.New(strategy.Configuration{
+		BranchingModel: "registry-test",
+		Options:        map[string]string{"method": "minor"},
+	})
+	require.NoError(t, err)
+
+	method, _ := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{})
+
+	assert.Equal(t, "minor", method)
+}
+
+func TestRegister_Panics(t *testing.T) {
+	factory := func(strategy.Configuration) (strategy.Strategy, error) {
+		return fixedStrategy{}, nil
+	}
+
+	tests := map[string]struct {
+		Name    string
+		Factory strategy.Factory
+	}{
+		"empty name": {
+			Factory: factory,
+		},
+		"nil factory": {
+			Name: "registry-test-nil",
+		},
+		"already registered": {
+			Name:    "git-flow",
+			Factory: factory,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Panics(t, func() {
+				strategy.Register(test.Name, test.Factory)
+			})
+		})
+	}
+}
+
+func TestNew_InvalidBranchingModel(t *testing.T) {
+	_, err := strategy.New(strategy.Configuration{BranchingModel: "release-flow"})
+	require.Error(t, err)
+
+	assert.Equal(t, "invalid branching model: release-flow", err.Error())
+}
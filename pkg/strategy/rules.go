@@ -0,0 +1,81 @@
+package strategy
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/gandarez/semver-action/pkg/regex"
+)
+
+type (
+	// Rule maps the source and dest branches to a bump method and version.
+	Rule struct {
+		// Source matches the source branch. Nil matches any branch.
+		Source regex.Regex
+		// Dest matches the dest branch. Nil matches any branch.
+		Dest regex.Regex
+		// Method is returned on match. Empty means no bump.
+		Method string
+		// Version is returned on match along with the method, if applicable.
+		Version string
+		// Description explains the match in the decision trace. Defaults to
+		// the position of the rule.
+		Description string
+	}
+
+	// Rules is an ordered list of rules where the first match wins.
+	Rules []Rule
+)
+
+// Matches returns true if both the source and dest branches match the rule.
+func (r Rule) Matches(source, dest string) bool {
+	if r.Source != nil && !r.Source.MatchString(source) {
+		return false
+	}
+
+	if r.Dest != nil && !r.Dest.MatchString(dest) {
+		return false
+	}
+
+	return true
+}
+
+// Decide returns the method and version of the first rule matching the source
+// and dest branches, recording it in the trace. It returns false if none matches.
+func (r Rules) Decide(params BumpParams) (string, string, bool) {
+	for i, rule := range r {
+		if !rule.Matches(params.SourceBranch, params.DestBranch) {
+			continue
+		}
+
+		description := rule.Description
+		if description == "" {
+			description = fmt.Sprintf("branches match rule %d", i+1)
+		}
+
+		params.Trace.Decide(description, rule.Source)
+
+		return rule.Method, rule.Version, true
+	}
+
+	return "", "", false
+}
+
+// withSources returns the rules having a source pattern. Built-in rules of unset
+// patterns never match instead of matching any branch.
+func (r Rules) withSources() Rules {
+	var rules Rules
+
+	for _, rule := range r {
+		if rule.Source != nil {
+			rules = append(rules, rule)
+		}
+	}
+
+	return rules
+}
+
+// branch returns a pattern matching exactly the branch name.
+func branch(name string) regex.Regex {
+	return regex.MustCompile("^" + regexp.QuoteMeta(name) + "$")
+}
@@ -0,0 +1,96 @@
+package strategy_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/semver-action/pkg/regex"
+	"github.com/gandarez/semver-action/pkg/strategy"
+	"github.com/gandarez/semver-action/pkg/trace"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRules_Decide(t *testing.T) {
+	rules := strategy.Rules{
+		{
+			Source: regex.MustCompile(`^chore/.+`),
+			Dest:   regex.MustCompile(`^main$`),
+		},
+		{
+			Source:      regex.MustCompile(`^deps/.+`),
+			Dest:        regex.MustCompile(`^develop$`),
+			Method:      "build",
+			Version:     "build",
+			Description: "dependencies into develop branch",
+		},
+		{
+			Source: regex.MustCompile(`^(chore|perf)/.+`),
+			Method: "patch",
+		},
+	}
+
+	tests := map[string]struct {
+		SourceBranch     string
+		DestBranch       string
+		ExpectedMethod   string
+		ExpectedVersion  string
+		ExpectedMatch    bool
+		ExpectedDecision string
+		ExpectedPattern  string
+	}{
+		"first match wins": {
+			SourceBranch:     "chore/some",
+			DestBranch:       "main",
+			ExpectedMatch:    true,
+			ExpectedDecision: "branches match rule 1",
+			ExpectedPattern:  "^chore/.+",
+		},
+		"dest branch not matching falls through": {
+			SourceBranch:     "chore/some",
+			DestBranch:       "develop",
+			ExpectedMethod:   "patch",
+			ExpectedMatch:    true,
+			ExpectedDecision: "branches match rule 3",
+			ExpectedPattern:  "^(chore|perf)/.+",
+		},
+		"description": {
+			SourceBranch:     "deps/some",
+			DestBranch:       "develop",
+			ExpectedMethod:   "build",
+			ExpectedVersion:  "build",
+			ExpectedMatch:    true,
+			ExpectedDecision: "dependencies into develop branch",
+			ExpectedPattern:  "^deps/.+",
+		},
+		"nil dest matches any branch": {
+			SourceBranch:     "perf/some",
+			DestBranch:       "release/1.x",
+			ExpectedMethod:   "patch",
+			ExpectedMatch:    true,
+			ExpectedDecision: "branches match rule 3",
+			ExpectedPattern:  "^(chore|perf)/.+",
+		},
+		"no match": {
+			SourceBranch: "feature/some",
+			DestBranch:   "main",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tr := &trace.Trace{}
+
+			method, version, ok := rules.Decide(strategy.BumpParams{
+				SourceBranch: test.SourceBranch,
+				DestBranch:   test.DestBranch,
+				Trace:        tr,
+			})
+
+			assert.Equal(t, test.ExpectedMatch, ok)
+			assert.Equal(t, test.ExpectedMethod, method)
+			assert.Equal(t, test.ExpectedVersion, version)
+			assert.Equal(t, test.ExpectedDecision, tr.Decision)
+			assert.Equal(t, test.ExpectedPattern, tr.Pattern)
+		})
+	}
+}
@@ -0,0 +1,240 @@
+package strategy
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gandarez/semver-action/internal/conventional"
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/regex"
+	"github.com/gandarez/semver-action/pkg/trace"
+
+	"github.com/blang/semver/v4"
+)
+
+type (
+	// Strategy defines the interface for a strategy.
+	Strategy interface {
+		// DetermineBumpStrategy determines the strategy for semver to bump product version.
+		// It returns the method to bump and the version part to bump, if applicable.
+		DetermineBumpStrategy(params BumpParams) (string, string)
+		Tag(params TagParams, gc git.Git) (Result, error)
+		Name() string
+	}
+
+	// Configuration contains the strategy configuration.
+	Configuration struct {
+		Bump              string
+		BumpSource        string
+		BranchingModel    string
+		MainBranchName    string
+		DevelopBranchName string
+		PatchPattern      regex.Regex
+		MinorPattern      regex.Regex
+		MajorPattern      regex.Regex
+		BuildPattern      regex.Regex
+		HotfixPattern     regex.Regex
+		ExcludePattern    regex.Regex
+		// Rules are evaluated in order before the built-in rules of git-flow,
+		// trunk-based and github-flow models, and the first match wins.
+		Rules Rules
+		// LabelBumps maps pull request labels to major, minor, patch or skip.
+		LabelBumps map[string]string
+		// InitialDevelopment downgrades major to minor and minor to patch while
+		// the major version is zero.
+		InitialDevelopment bool
+		// MaintenancePattern matches maintenance branches in trunk-based model.
+		MaintenancePattern regex.Regex
+		// CalVerFormat is the format of calver model, e.g. YYYY.MM.MICRO.
+		CalVerFormat string
+		// Now returns the current time for calver model. Defaults to time.Now in UTC.
+		Now func() time.Time
+		// Options contains strategy specific options of registered strategies,
+		// which are not used by the built-in ones.
+		Options map[string]string
+	}
+
+	// BumpParams contains the parameters for DetermineBumpStrategy().
+	BumpParams struct {
+		SourceBranch string
+		DestBranch   string
+		// Commits contains the commits since the latest tag. It's only
+		// consulted when bump source is "commits".
+		Commits []git.Commit
+		// Labels contains the pull request labels, mapped by the label bumps
+		// of the configuration.
+		Labels []string
+		// Trace records the decision, if set.
+		Trace *trace.Trace
+	}
+
+	// TagParams contains the parameters for Tag().
+	TagParams struct {
+		DestBranch   string
+		Method       string
+		Prefix       string
+		PrereleaseID string
+		LatestTag    string
+		Tag          *semver.Version
+		Version      string
+		// PullRequest is the number of the unmerged pull request being built,
+		// or zero. It's only consulted by the github-flow strategy.
+		PullRequest int
+		// Trace records each increment applied, if set.
+		Trace *trace.Trace
+	}
+
+	// Result contains the result of strategy execution.
+	Result struct {
+		AncestorTag  string
+		SemverTag    string
+		IsPrerelease bool
+	}
+)
+
+// New returns the strategy registered as the branching model of config. The
+// rules are validated against the features of strategies implementing Describer.
+func New(config Configuration) (Strategy, error) {
+	factory, ok := lookup(config.BranchingModel)
+	if !ok {
+		return nil, fmt.Errorf("invalid branching model: %s", config.BranchingModel)
+	}
+
+	s, err := factory(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if d, ok := s.(Describer); ok {
+		if err := d.Features().validateRules(config.BranchingModel, config.Rules); err != nil {
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// MaintenanceLine returns the version line, e.g. "1.4" or "1", of the branch
+// matching the maintenance pattern. The first capture group of pattern is the
+// major version and the optional second one the minor version, where "x" or
+// empty means any minor.
+func MaintenanceLine(pattern regex.Regex, branch string) (string, bool) {
+	if pattern == nil {
+		return "", false
+	}
+
+	match := pattern.FindStringSubmatch(branch)
+	if len(match) < 2 {
+		return "", false
+	}
+
+	major, err := strconv.ParseUint(match[1], 10, 64)
+	if err != nil {
+		return "", false
+	}
+
+	if len(match) < 3 || match[2] == "" || strings.EqualFold(match[2], "x") {
+		return strconv.FormatUint(major, 10), true
+	}
+
+	minor, err := strconv.ParseUint(match[2], 10, 64)
+	if err != nil {
+		return "", false
+	}
+
+	return fmt.Sprintf("%d.%d", major, minor), true
+}
+
+// initialDevelopmentLevel returns the version part to increment following the
+// 0.y.z rules of SemVer, where anything may change at any time. While the major
+// version is zero, major is downgraded to minor and minor to patch.
+func initialDevelopmentLevel(level string, tag *semver.Version, tr *trace.Trace) string {
+	if tag.Major != 0 {
+		return level
+	}
+
+	switch level {
+	case "major":
+		tr.Notef("initial development, major downgraded to minor")
+		return "minor"
+	case "minor":
+		tr.Notef("initial development, minor downgraded to patch")
+		return "patch"
+	default:
+		return level
+	}
+}
+
+// graduate sets the tag to 1.0.0, releasing the first stable version after
+// initial development.
+func graduate(params TagParams) error {
+	if params.Tag.Major != 0 {
+		return fmt.Errorf("failed to graduate version %s: initial development is already over", params.Tag)
+	}
+
+	before := params.Tag.String()
+
+	*params.Tag = semver.Version{Major: 1}
+
+	params.Trace.Increment("graduate from initial development", before, params.Tag.String())
+
+	return nil
+}
+
+// commitsBump returns the highest version part required by the commits
+// following Conventional Commits. It returns empty if none requires a bump.
+func commitsBump(commits []git.Commit) string {
+	messages := make([]string, len(commits))
+	for i, commit := range commits {
+		messages[i] = commit.Message
+	}
+
+	return conventional.Bump(messages...)
+}
+
+// labelsBump returns the bump of the labels and the label deciding it. Skip
+// wins over major, major over minor and minor over patch. It returns empty if
+// no label is mapped.
+func labelsBump(labels []string, labelBumps map[string]string) (string, string) {
+	var bump, decidingLabel string
+
+	for _, label := range labels {
+		mapped, ok := labelBumps[label]
+		if !ok {
+			continue
+		}
+
+		if labelBumpPriority(mapped) > labelBumpPriority(bump) {
+			bump, decidingLabel = mapped, label
+		}
+	}
+
+	return bump, decidingLabel
+}
+
+// noteIgnoredLabel records in the trace that the label deciding the bump is
+// ignored for the reason. It's a no-op if no label is mapped.
+func noteIgnoredLabel(tr *trace.Trace, label, reason string) {
+	if label == "" {
+		return
+	}
+
+	tr.Notef("pull request label %s is ignored %s", label, reason)
+}
+
+func labelBumpPriority(bump string) int {
+	switch bump {
+	case "skip":
+		return 4
+	case "major":
+		return 3
+	case "minor":
+		return 2
+	case "patch":
+		return 1
+	default:
+		return 0
+	}
+}
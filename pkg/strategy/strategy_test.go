@@ -0,0 +1,216 @@
+package strategy_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/regex"
+	"github.com/gandarez/semver-action/pkg/strategy"
+
+	"github.com/blang/semver/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type gitClientMock struct {
+	CurrentBranchFn          func() (string, error)
+	CurrentBranchFnInvoked   int
+	IsRepoFn                 func() bool
+	IsRepoFnInvoked          int
+	MakeSafeFn               func() error
+	MakeSafeFnInvoked        int
+	LatestTagFn              func(include, exclude string) string
+	LatestTagFnInvoked       int
+	AncestorTagFn            func(include, exclude, branch string) string
+	AncestorTagFnInvoked     int
+	SourceBranchFn           func(commitHash string) (string, error)
+	SourceBranchFnInvoked    int
+	TrailersFn               func(commitHash string) ([]git.Trailer, error)
+	TrailersFnInvoked        int
+	CommitsFn                func(from, to string) ([]git.Commit, error)
+	CommitsFnInvoked         int
+	MainlineCommitsFn        func(from, to string) ([]git.Commit, error)
+	MainlineCommitsFnInvoked int
+	ChangedFilesFn           func(from, to string) ([]string, error)
+	ChangedFilesFnInvoked    int
+	TagsFn                   func(pattern string) ([]string, error)
+	TagsFnInvoked            int
+	TagExistsFn              func(name string) bool
+	TagExistsFnInvoked       int
+	CreateTagFn              func(name, commitHash, message string) error
+	CreateTagFnInvoked       int
+	PushTagFn                func(remote, name string) error
+	PushTagFnInvoked         int
+	AddWorktreeFn            func(dir, commitish string) error
+	AddWorktreeFnInvoked     int
+	RemoveWorktreeFn         func(dir string) error
+	RemoveWorktreeFnInvoked  int
+}
+
+func initGitClientMock(
+	t *testing.T,
+	latestTag,
+	ancestorTag,
+	currentBranch,
+	sourceBranch,
+	expectedCommitHash string) *gitClientMock {
+	return &gitClientMock{
+		CurrentBranchFn: func() (string, error) {
+			return currentBranch, nil
+		},
+		IsRepoFn: func() bool {
+			return true
+		},
+		MakeSafeFn: func() error {
+			return nil
+		},
+		LatestTagFn: func(include, exclude string) string {
+			return latestTag
+		},
+		AncestorTagFn: func(include, exclude, branch string) string {
+			return ancestorTag
+		},
+		SourceBranchFn: func(commitHash string) (string, error) {
+			assert.Equal(t, expectedCommitHash, commitHash)
+			return sourceBranch, nil
+		},
+	}
+}
+
+func (m *gitClientMock) CurrentBranch() (string, error) {
+	m.CurrentBranchFnInvoked++
+	return m.CurrentBranchFn()
+}
+
+func (m *gitClientMock) MakeSafe() error {
+	m.MakeSafeFnInvoked++
+	return m.MakeSafeFn()
+}
+
+func (m *gitClientMock) IsRepo() bool {
+	m.IsRepoFnInvoked++
+	return m.IsRepoFn()
+}
+
+func (m *gitClientMock) LatestTag(include, exclude string) string {
+	m.LatestTagFnInvoked++
+	return m.LatestTagFn(include, exclude)
+}
+
+func (m *gitClientMock) AncestorTag(include, exclude, branch string) string {
+	m.AncestorTagFnInvoked++
+	return m.AncestorTagFn(include, exclude, branch)
+}
+
+func (m *gitClientMock) SourceBranch(commitHash string) (string, error) {
+	m.SourceBranchFnInvoked++
+	return m.SourceBranchFn(commitHash)
+}
+
+func (m *gitClientMock) Trailers(commitHash string) ([]git.Trailer, error) {
+	m.TrailersFnInvoked++
+	return m.TrailersFn(commitHash)
+}
+
+func (m *gitClientMock) Commits(from, to string) ([]git.Commit, error) {
+	m.CommitsFnInvoked++
+	return m.CommitsFn(from, to)
+}
+
+func (m *gitClientMock) MainlineCommits(from, to string) ([]git.Commit, error) {
+	m.MainlineCommitsFnInvoked++
+	return m.MainlineCommitsFn(from, to)
+}
+
+func (m *gitClientMock) ChangedFiles(from, to string) ([]string, error) {
+	m.ChangedFilesFnInvoked++
+	return m.ChangedFilesFn(from, to)
+}
+
+func (m *gitClientMock) Tags(pattern string) ([]string, error) {
+	m.TagsFnInvoked++
+	return m.TagsFn(pattern)
+}
+
+func (m *gitClientMock) TagExists(name string) bool {
+	m.TagExistsFnInvoked++
+	return m.TagExistsFn(name)
+}
+
+func (m *gitClientMock) CreateTag(name, commitHash, message string) error {
+	m.CreateTagFnInvoked++
+	return m.CreateTagFn(name, commitHash, message)
+}
+
+func (m *gitClientMock) PushTag(remote, name string) error {
+	m.PushTagFnInvoked++
+	return m.PushTagFn(remote, name)
+}
+
+func (m *gitClientMock) AddWorktree(dir, commitish string) error {
+	m.AddWorktreeFnInvoked++
+	return m.AddWorktreeFn(dir, commitish)
+}
+
+func (m *gitClientMock) RemoveWorktree(dir string) error {
+	m.RemoveWorktreeFnInvoked++
+	return m.RemoveWorktreeFn(dir)
+}
+
+func newSemVerPtr(t *testing.T, s string) *semver.Version {
+	version, err := semver.New(s)
+	require.NoError(t, err)
+
+	return version
+}
+
+func TestMaintenanceLine(t *testing.T) {
+	tests := map[string]struct {
+		Pattern      regex.Regex
+		Branch       string
+		ExpectedLine string
+		ExpectedOk   bool
+	}{
+		"major and minor": {
+			Pattern:      regex.MustCompile(`(?i)^release/(\d+)\.(\d+|x)$`),
+			Branch:       "release/1.4",
+			ExpectedLine: "1.4",
+			ExpectedOk:   true,
+		},
+		"any minor": {
+			Pattern:      regex.MustCompile(`(?i)^release/(\d+)\.(\d+|x)$`),
+			Branch:       "release/1.x",
+			ExpectedLine: "1",
+			ExpectedOk:   true,
+		},
+		"major only": {
+			Pattern:      regex.MustCompile(`^support/v(\d+)$`),
+			Branch:       "support/v2",
+			ExpectedLine: "2",
+			ExpectedOk:   true,
+		},
+		"not a number": {
+			Pattern:    regex.MustCompile(`^release/(.+)$`),
+			Branch:     "release/next",
+			ExpectedOk: false,
+		},
+		"no match": {
+			Pattern:    regex.MustCompile(`(?i)^release/(\d+)\.(\d+|x)$`),
+			Branch:     "master",
+			ExpectedOk: false,
+		},
+		"no pattern": {
+			Branch:     "release/1.4",
+			ExpectedOk: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			line, ok := strategy.MaintenanceLine(test.Pattern, test.Branch)
+
+			assert.Equal(t, test.ExpectedOk, ok)
+			assert.Equal(t, test.ExpectedLine, line)
+		})
+	}
+}
@@ -0,0 +1,246 @@
+package strategy
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/apex/log"
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/regex"
+
+	"github.com/blang/semver/v4"
+)
+
+// TrunkBased implements the trunk-based strategy.
+type TrunkBased struct {
+	bump           string
+	bumpSource     string
+	branchName     string
+	excludePattern regex.Regex
+	// labelBumps maps pull request labels to major, minor, patch or skip.
+	labelBumps map[string]string
+	// rules are the configured rules followed by the built-in ones.
+	rules Rules
+	// maintenancePattern matches maintenance branches, where only patches are released.
+	maintenancePattern regex.Regex
+	// initialDevelopment downgrades major to minor and minor to patch while
+	// the major version is zero.
+	initialDevelopment bool
+}
+
+// NewTrunkBased returns the trunk-based strategy, registered as "trunk-based".
+func NewTrunkBased(config Configuration) (Strategy, error) {
+	return &TrunkBased{
+		bump:               config.Bump,
+		bumpSource:         config.BumpSource,
+		branchName:         config.MainBranchName,
+		excludePattern:     config.ExcludePattern,
+		labelBumps:         config.LabelBumps,
+		rules:              append(append(Rules{}, config.Rules...), trunkBasedRules(config)...),
+		maintenancePattern: config.MaintenancePattern,
+		initialDevelopment: config.InitialDevelopment,
+	}, nil
+}
+
+// trunkBasedRules returns the built-in rules of trunk-based model.
+func trunkBasedRules(config Configuration) Rules {
+	main := branch(config.MainBranchName)
+
+	return Rules{
+		// bugfix into main branch
+		{
+			Source:      config.PatchPattern,
+			Dest:        main,
+			Method:      "patch",
+			Description: "source branch matches patch pattern into main branch",
+		},
+		// feature into main branch
+		{
+			Source:      config.MinorPattern,
+			Dest:        main,
+			Method:      "minor",
+			Description: "source branch matches minor pattern into main branch",
+		},
+		// major into main branch
+		{
+			Source:      config.MajorPattern,
+			Dest:        main,
+			Method:      "major",
+			Description: "source branch matches major pattern into main branch",
+		},
+		// build into main branch
+		{
+			Source:      config.BuildPattern,
+			Dest:        main,
+			Method:      "build",
+			Description: "source branch matches build pattern into main branch",
+		},
+	}.withSources()
+}
+
+// DetermineBumpStrategy determines the strategy for semver to bump product version.
+func (t *TrunkBased) DetermineBumpStrategy(params BumpParams) (string, string) {
+	sourceBranch, destBranch := params.SourceBranch, params.DestBranch
+
+	// if source branch is excluded, do not bump
+	if t.excludePattern != nil && t.excludePattern.MatchString(sourceBranch) {
+		params.Trace.Decide("source branch matches exclude pattern", t.excludePattern)
+		return "", ""
+	}
+
+	labelBump, label := labelsBump(params.Labels, t.labelBumps)
+
+	// if a pull request label skips the bump, do not bump
+	if labelBump == "skip" {
+		params.Trace.Decide(fmt.Sprintf("pull request label %s skips the bump", label), nil)
+		return "", ""
+	}
+
+	// maintenance branches only ever get patches, but rules can still skip the bump
+	if _, ok := MaintenanceLine(t.maintenancePattern, destBranch); ok {
+		if t.bump == "auto" {
+			if method, _, ok := t.rules.Decide(params); ok && method == "" {
+				return "", ""
+			}
+		}
+
+		noteIgnoredLabel(params.Trace, label, "into maintenance branch")
+
+		params.Trace.Decide("dest branch matches maintenance pattern", t.maintenancePattern)
+		return "patch", ""
+	}
+
+	// if bump is not auto, return it
+	if t.bump != "auto" {
+		noteIgnoredLabel(params.Trace, label, fmt.Sprintf("as bump is %s", t.bump))
+
+		params.Trace.Decide(fmt.Sprintf("bump is %s", t.bump), nil)
+		return t.bump, ""
+	}
+
+	// pull request labels take precedence over commits and branch patterns
+	if labelBump != "" && destBranch == t.branchName {
+		params.Trace.Decide(fmt.Sprintf("pull request label %s requires %s into main branch", label, labelBump), nil)
+		return labelBump, ""
+	}
+
+	noteIgnoredLabel(params.Trace, label, fmt.Sprintf("into %s branch", destBranch))
+
+	// conventional commits into main branch, falls back to branch patterns
+	if t.bumpSource == "commits" && destBranch == t.branchName {
+		if version := commitsBump(params.Commits); version != "" {
+			params.Trace.Decide(fmt.Sprintf("conventional commits require %s into main branch", version), nil)
+			return version, ""
+		}
+	}
+
+	if method, version, ok := t.rules.Decide(params); ok {
+		return method, version
+	}
+
+	params.Trace.Decide("source branch matches no pattern", nil)
+
+	return "build", ""
+}
+
+// Tag implements the Strategy interface.
+func (t *TrunkBased) Tag(params TagParams, gc git.Git) (Result, error) {
+	var finalTag string
+
+	if t.initialDevelopment {
+		params.Method = initialDevelopmentLevel(params.Method, params.Tag, params.Trace)
+	}
+
+	switch params.Method {
+	case "build":
+		{
+			before := params.Tag.String()
+
+			buildNumberStr, _ := semver.NewBuildVersion("0")
+
+			if len(params.Tag.Build) > 0 && params.Version == "" {
+				buildNumberStr = params.Tag.Build[len(params.Tag.Build)-1]
+			}
+
+			buildNumber, _ := strconv.Atoi(buildNumberStr)
+			buildNumber++
+
+			params.Tag.Build = []string{strconv.Itoa(buildNumber)}
+
+			params.Trace.Increment("increment build number", before, params.Tag.String())
+
+			finalTag = params.Prefix + params.Tag.String()
+		}
+	case "major":
+		{
+			log.Debug("incrementing major")
+
+			before := params.Tag.String()
+
+			if err := params.Tag.IncrementMajor(); err != nil {
+				return Result{}, fmt.Errorf("failed to increment major version: %s", err)
+			}
+
+			params.Trace.Increment("increment major", before, params.Tag.FinalizeVersion())
+
+			finalTag = params.Prefix + params.Tag.FinalizeVersion()
+		}
+	case "minor":
+		{
+			log.Debug("incrementing minor")
+
+			before := params.Tag.String()
+
+			if err := params.Tag.IncrementMinor(); err != nil {
+				return Result{}, fmt.Errorf("failed to increment minor version: %s", err)
+			}
+
+			params.Trace.Increment("increment minor", before, params.Tag.FinalizeVersion())
+
+			finalTag = params.Prefix + params.Tag.FinalizeVersion()
+		}
+	case "patch":
+		{
+			log.Debug("incrementing patch")
+
+			before := params.Tag.String()
+
+			if err := params.Tag.IncrementPatch(); err != nil {
+				return Result{}, fmt.Errorf("failed to increment patch version: %s", err)
+			}
+
+			params.Trace.Increment("increment patch", before, params.Tag.FinalizeVersion())
+
+			finalTag = params.Prefix + params.Tag.FinalizeVersion()
+		}
+	case "graduate":
+		if err := graduate(params); err != nil {
+			return Result{}, err
+		}
+
+		finalTag = params.Prefix + params.Tag.String()
+	default:
+		finalTag = params.Prefix + params.Tag.FinalizeVersion()
+	}
+
+	return Result{
+		AncestorTag:  "",
+		SemverTag:    finalTag,
+		IsPrerelease: false,
+	}, nil
+}
+
+// Features implements the Describer interface.
+func (t *TrunkBased) Features() Features {
+	return Features{
+		Methods:          []string{"build", "major", "minor", "patch", "graduate"},
+		BuildBranches:    []string{t.branchName},
+		MaintenanceLines: true,
+		Rules:            true,
+	}
+}
+
+// Name returns the name of the strategy.
+func (TrunkBased) Name() string {
+	return "trunk-based"
+}
@@ -0,0 +1,538 @@
+package strategy_test
+
+import (
+	"testing"
+
+	"github.com/blang/semver/v4"
+	"github.com/gandarez/semver-action/pkg/git"
+	"github.com/gandarez/semver-action/pkg/regex"
+	"github.com/gandarez/semver-action/pkg/strategy"
+	"github.com/gandarez/semver-action/pkg/trace"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetermineBumpStrategy_TrunkBased(t *testing.T) {
+	tests := map[string]struct {
+		SourceBranch   string
+		DestBranch     string
+		Bump           string
+		ExcludePattern regex.Regex
+		ExpectedMethod string
+	}{
+		"source branch bugfix, dest branch master and auto bump": {
+			SourceBranch:   "bugfix/some",
+			DestBranch:     "master",
+			Bump:           "auto",
+			ExpectedMethod: "patch",
+		},
+		"source branch feature, dest branch master and auto bump": {
+			SourceBranch:   "feature/some",
+			DestBranch:     "master",
+			Bump:           "auto",
+			ExpectedMethod: "minor",
+		},
+		"source branch major, dest branch master and auto bump": {
+			SourceBranch:   "major/some",
+			DestBranch:     "master",
+			Bump:           "auto",
+			ExpectedMethod: "major",
+		},
+		"source branch build, dest branch master and auto bump": {
+			SourceBranch:   "doc/some",
+			DestBranch:     "master",
+			Bump:           "auto",
+			ExpectedMethod: "build",
+		},
+		"source branch ignore": {
+			SourceBranch:   "ignore/some",
+			ExcludePattern: regex.MustCompile(`(?i)^ignore/.+`),
+			ExpectedMethod: "",
+		},
+		"source branch ignore, no exclude pattern": {
+			SourceBranch:   "ignore/some",
+			ExpectedMethod: "",
+		},
+		"source branch ignore, no exclude pattern and auto bump": {
+			SourceBranch:   "ignore/some",
+			Bump:           "auto",
+			ExpectedMethod: "build",
+		},
+		"not a valid source branch prefix and auto bump": {
+			SourceBranch:   "some-branch",
+			Bump:           "auto",
+			ExpectedMethod: "build",
+		},
+		"patch bump": {
+			Bump:           "patch",
+			ExpectedMethod: "patch",
+		},
+		"minor bump": {
+			Bump:           "minor",
+			ExpectedMethod: "minor",
+		},
+		"major bump": {
+			Bump:           "major",
+			ExpectedMethod: "major",
+		},
+		"source branch feature, dest branch maintenance and auto bump": {
+			SourceBranch:   "feature/some",
+			DestBranch:     "release/1.4",
+			Bump:           "auto",
+			ExpectedMethod: "patch",
+		},
+		"dest branch maintenance and major bump": {
+			DestBranch:     "release/1.x",
+			Bump:           "major",
+			ExpectedMethod: "patch",
+		},
+		"source branch ignore, dest branch maintenance": {
+			SourceBranch:   "ignore/some",
+			DestBranch:     "release/1.4",
+			Bump:           "auto",
+			ExcludePattern: regex.MustCompile(`(?i)^ignore/.+`),
+			ExpectedMethod: "",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				Bump:               test.Bump,
+				BranchingModel:     "trunk-based",
+				MainBranchName:     "master",
+				PatchPattern:       regex.MustCompile(`(?i)^bugfix/.+`),
+				MinorPattern:       regex.MustCompile(`(?i)^feature/.+`),
+				MajorPattern:       regex.MustCompile(`(?i)^major/.+`),
+				BuildPattern:       regex.MustCompile(`(?i)^(doc(s)?|misc)/.+`),
+				ExcludePattern:     test.ExcludePattern,
+				MaintenancePattern: regex.MustCompile(`(?i)^release/(\d+)\.(\d+|x)$`),
+			})
+			require.NoError(t, err)
+
+			method, version := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+				SourceBranch: test.SourceBranch,
+				DestBranch:   test.DestBranch,
+			})
+
+			assert.Equal(t, test.ExpectedMethod, method)
+			assert.Empty(t, version)
+		})
+	}
+}
+
+func TestDetermineBumpStrategy_TrunkBased_Commits(t *testing.T) {
+	tests := map[string]struct {
+		SourceBranch   string
+		DestBranch     string
+		Commits        []git.Commit
+		ExpectedMethod string
+	}{
+		"breaking change marker into master": {
+			SourceBranch: "some-branch",
+			DestBranch:   "master",
+			Commits: []git.Commit{
+				{Message: "feat!: new api"},
+			},
+			ExpectedMethod: "major",
+		},
+		"fix commit into master": {
+			SourceBranch: "feature/some",
+			DestBranch:   "master",
+			Commits: []git.Commit{
+				{Message: "chore: bump deps"},
+				{Message: "fix(parser): handle empty input"},
+			},
+			ExpectedMethod: "patch",
+		},
+		"no conventional commits falls back to branch pattern": {
+			SourceBranch: "feature/some",
+			DestBranch:   "master",
+			Commits: []git.Commit{
+				{Message: "update readme"},
+			},
+			ExpectedMethod: "minor",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				Bump:           "auto",
+				BumpSource:     "commits",
+				BranchingModel: "trunk-based",
+				MainBranchName: "master",
+				PatchPattern:   regex.MustCompile(`(?i)^bugfix/.+`),
+				MinorPattern:   regex.MustCompile(`(?i)^feature/.+`),
+				MajorPattern:   regex.MustCompile(`(?i)^major/.+`),
+				BuildPattern:   regex.MustCompile(`(?i)^(doc(s)?|misc)/.+`),
+			})
+			require.NoError(t, err)
+
+			method, version := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+				SourceBranch: test.SourceBranch,
+				DestBranch:   test.DestBranch,
+				Commits:      test.Commits,
+			})
+
+			assert.Equal(t, test.ExpectedMethod, method)
+			assert.Empty(t, version)
+		})
+	}
+}
+
+func TestDetermineBumpStrategy_TrunkBased_Rules(t *testing.T) {
+	tests := map[string]struct {
+		SourceBranch   string
+		DestBranch     string
+		ExpectedMethod string
+	}{
+		"rule without bump": {
+			SourceBranch: "chore/some",
+			DestBranch:   "master",
+		},
+		"rule overrides built-in rule": {
+			SourceBranch:   "docs/some",
+			DestBranch:     "master",
+			ExpectedMethod: "patch",
+		},
+		"falls back to built-in rules": {
+			SourceBranch:   "feature/some",
+			DestBranch:     "master",
+			ExpectedMethod: "minor",
+		},
+		"falls back to no pattern": {
+			SourceBranch:   "some-branch",
+			DestBranch:     "master",
+			ExpectedMethod: "build",
+		},
+		"rule without bump into maintenance branch": {
+			SourceBranch: "chore/some",
+			DestBranch:   "release/1.x",
+		},
+		"rule with bump into maintenance branch": {
+			SourceBranch:   "perf/some",
+			DestBranch:     "release/1.x",
+			ExpectedMethod: "patch",
+		},
+		"no rule into maintenance branch": {
+			SourceBranch:   "feature/some",
+			DestBranch:     "release/1.x",
+			ExpectedMethod: "patch",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				Bump:               "auto",
+				BranchingModel:     "trunk-based",
+				MainBranchName:     "master",
+				PatchPattern:       regex.MustCompile(`(?i)^bugfix/.+`),
+				MinorPattern:       regex.MustCompile(`(?i)^feature/.+`),
+				MajorPattern:       regex.MustCompile(`(?i)^major/.+`),
+				BuildPattern:       regex.MustCompile(`(?i)^(doc(s)?|misc)/.+`),
+				MaintenancePattern: regex.MustCompile(`(?i)^release/(\d+)\.(\d+|x)$`),
+				Rules: strategy.Rules{
+					{Source: regex.MustCompile(`^chore/.+`)},
+					{Source: regex.MustCompile(`^(perf|docs)/.+`), Method: "patch"},
+				},
+			})
+			require.NoError(t, err)
+
+			method, version := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+				SourceBranch: test.SourceBranch,
+				DestBranch:   test.DestBranch,
+			})
+
+			assert.Equal(t, test.ExpectedMethod, method)
+			assert.Empty(t, version)
+		})
+	}
+}
+
+func TestDetermineBumpStrategy_TrunkBased_Labels(t *testing.T) {
+	tests := map[string]struct {
+		SourceBranch   string
+		DestBranch     string
+		Labels         []string
+		ExpectedMethod string
+		ExpectedNotes  []string
+	}{
+		"label overrides branch pattern": {
+			SourceBranch:   "feature/some",
+			DestBranch:     "master",
+			Labels:         []string{"semver:patch"},
+			ExpectedMethod: "patch",
+		},
+		"label overrides commits": {
+			SourceBranch:   "some-branch",
+			DestBranch:     "master",
+			Labels:         []string{"semver:minor"},
+			ExpectedMethod: "minor",
+		},
+		"skip label": {
+			SourceBranch: "bugfix/some",
+			DestBranch:   "master",
+			Labels:       []string{"semver:skip"},
+		},
+		"skip label into maintenance branch": {
+			SourceBranch: "bugfix/some",
+			DestBranch:   "release/1.2",
+			Labels:       []string{"semver:skip"},
+		},
+		"label into maintenance branch": {
+			SourceBranch:   "feature/some",
+			DestBranch:     "release/1.2",
+			Labels:         []string{"semver:minor"},
+			ExpectedMethod: "patch",
+			ExpectedNotes:  []string{"pull request label semver:minor is ignored into maintenance branch"},
+		},
+		"label into other branch": {
+			SourceBranch:   "feature/some",
+			DestBranch:     "staging",
+			Labels:         []string{"semver:major"},
+			ExpectedMethod: "build",
+			ExpectedNotes:  []string{"pull request label semver:major is ignored into staging branch"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				Bump:               "auto",
+				BumpSource:         "commits",
+				BranchingModel:     "trunk-based",
+				MainBranchName:     "master",
+				PatchPattern:       regex.MustCompile(`(?i)^bugfix/.+`),
+				MinorPattern:       regex.MustCompile(`(?i)^feature/.+`),
+				MajorPattern:       regex.MustCompile(`(?i)^major/.+`),
+				BuildPattern:       regex.MustCompile(`(?i)^(doc(s)?|misc)/.+`),
+				MaintenancePattern: regex.MustCompile(`(?i)^release/(\d+)\.(\d+|x)$`),
+				LabelBumps: map[string]string{
+					"semver:major": "major",
+					"semver:minor": "minor",
+					"semver:patch": "patch",
+					"semver:skip":  "skip",
+				},
+			})
+			require.NoError(t, err)
+
+			tr := &trace.Trace{}
+
+			method, version := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+				SourceBranch: test.SourceBranch,
+				DestBranch:   test.DestBranch,
+				Commits:      []git.Commit{{Message: "feat!: new api"}},
+				Labels:       test.Labels,
+				Trace:        tr,
+			})
+
+			assert.Equal(t, test.ExpectedMethod, method)
+			assert.Empty(t, version)
+			assert.Equal(t, test.ExpectedNotes, tr.Notes)
+		})
+	}
+}
+
+func TestTag_Trunkbased(t *testing.T) {
+	tests := map[string]struct {
+		Method   string
+		Tag      *semver.Version
+		Version  string
+		Expected strategy.Result
+	}{
+		"build": {
+			Method: "build",
+			Tag:    newSemVerPtr(t, "1.2.3"),
+			Expected: strategy.Result{
+				SemverTag:    "v1.2.3+1",
+				IsPrerelease: false,
+			},
+		},
+		"build with previous tag containing build": {
+			Method: "build",
+			Tag:    newSemVerPtr(t, "1.2.3+1"),
+			Expected: strategy.Result{
+				SemverTag:    "v1.2.3+2",
+				IsPrerelease: false,
+			},
+		},
+		"major": {
+			Method: "major",
+			Tag:    newSemVerPtr(t, "1.2.3"),
+			Expected: strategy.Result{
+				SemverTag:    "v2.0.0",
+				IsPrerelease: false,
+			},
+		},
+		"minor": {
+			Method: "minor",
+			Tag:    newSemVerPtr(t, "1.2.3"),
+			Expected: strategy.Result{
+				SemverTag:    "v1.3.0",
+				IsPrerelease: false,
+			},
+		},
+		"patch": {
+			Method: "patch",
+			Tag:    newSemVerPtr(t, "1.2.3"),
+			Expected: strategy.Result{
+				SemverTag:    "v1.2.4",
+				IsPrerelease: false,
+			},
+		},
+		"default": {
+			Method: "not-in-use",
+			Tag:    newSemVerPtr(t, "1.2.3"),
+			Expected: strategy.Result{
+				SemverTag:    "v1.2.3",
+				IsPrerelease: false,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tb := strategy.TrunkBased{}
+
+			gc := initGitClientMock(
+				t, "", "", "", "", "",
+			)
+
+			result, err := tb.Tag(strategy.TagParams{
+				DestBranch:   "not-used",
+				Prefix:       "v",
+				PrereleaseID: "alpha",
+				Method:       test.Method,
+				Tag:          test.Tag,
+				Version:      test.Version,
+			}, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result)
+		})
+	}
+}
+
+func TestTag_Trunkbased_InitialDevelopment(t *testing.T) {
+	tests := map[string]struct {
+		Method   string
+		Tag      *semver.Version
+		Expected string
+	}{
+		"major downgraded to minor": {
+			Method:   "major",
+			Tag:      newSemVerPtr(t, "0.4.2"),
+			Expected: "v0.5.0",
+		},
+		"minor downgraded to patch": {
+			Method:   "minor",
+			Tag:      newSemVerPtr(t, "0.4.2"),
+			Expected: "v0.4.3",
+		},
+		"patch": {
+			Method:   "patch",
+			Tag:      newSemVerPtr(t, "0.4.2"),
+			Expected: "v0.4.3",
+		},
+		"major after initial development": {
+			Method:   "major",
+			Tag:      newSemVerPtr(t, "1.4.2"),
+			Expected: "v2.0.0",
+		},
+		"graduate": {
+			Method:   "graduate",
+			Tag:      newSemVerPtr(t, "0.4.2+3"),
+			Expected: "v1.0.0",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			branchingStrategy, err := strategy.New(strategy.Configuration{
+				BranchingModel:     "trunk-based",
+				InitialDevelopment: true,
+			})
+			require.NoError(t, err)
+
+			gc := initGitClientMock(t, "", "", "", "", "")
+
+			result, err := branchingStrategy.Tag(strategy.TagParams{
+				Prefix: "v",
+				Method: test.Method,
+				Tag:    test.Tag,
+			}, gc)
+			require.NoError(t, err)
+
+			assert.Equal(t, test.Expected, result.SemverTag)
+		})
+	}
+}
+
+func TestTag_Trunkbased_GraduateErr(t *testing.T) {
+	tb := strategy.TrunkBased{}
+
+	gc := initGitClientMock(t, "", "", "", "", "")
+
+	_, err := tb.Tag(strategy.TagParams{
+		Prefix: "v",
+		Method: "graduate",
+		Tag:    newSemVerPtr(t, "1.4.2"),
+	}, gc)
+	require.Error(t, err)
+
+	assert.Equal(t, "failed to graduate version 1.4.2: initial development is already over", err.Error())
+}
+
+func TestTrunkBased_Trace(t *testing.T) {
+	branchingStrategy, err := strategy.New(strategy.Configuration{
+		Bump:           "auto",
+		BranchingModel: "trunk-based",
+		MainBranchName: "master",
+		PatchPattern:   regex.MustCompile(`(?i)^bugfix/.+`),
+		MinorPattern:   regex.MustCompile(`(?i)^feature/.+`),
+		MajorPattern:   regex.MustCompile(`(?i)^major/.+`),
+		BuildPattern:   regex.MustCompile(`(?i)^(doc(s)?|misc)/.+`),
+		ExcludePattern: regex.MustCompile(`(?i)^ignore/.+`),
+	})
+	require.NoError(t, err)
+
+	tr := &trace.Trace{}
+
+	method, _ := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+		SourceBranch: "ignore/some",
+		DestBranch:   "master",
+		Trace:        tr,
+	})
+
+	assert.Empty(t, method)
+	assert.Equal(t, "source branch matches exclude pattern", tr.Decision)
+	assert.Equal(t, "(?i)^ignore/.+", tr.Pattern)
+
+	method, version := branchingStrategy.DetermineBumpStrategy(strategy.BumpParams{
+		SourceBranch: "some-branch",
+		DestBranch:   "master",
+		Trace:        tr,
+	})
+
+	assert.Equal(t, "source branch matches no pattern", tr.Decision)
+	assert.Empty(t, tr.Pattern)
+
+	gc := initGitClientMock(t, "", "", "", "", "")
+
+	result, err := branchingStrategy.Tag(strategy.TagParams{
+		Method:  method,
+		Version: version,
+		Prefix:  "v",
+		Tag:     newSemVerPtr(t, "1.2.3+4"),
+		Trace:   tr,
+	}, gc)
+	require.NoError(t, err)
+
+	assert.Equal(t, "v1.2.3+5", result.SemverTag)
+	assert.Equal(t, []trace.Increment{
+		{Description: "increment build number", From: "1.2.3+4", To: "1.2.3+5"},
+	}, tr.Increments)
+}
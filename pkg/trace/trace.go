@@ -0,0 +1,176 @@
+package trace
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gandarez/semver-action/pkg/regex"
+)
+
+type (
+	// Trace records the decisions taken to calculate a version. All methods
+	// are no-op on a nil Trace, so callers don't need to check it.
+	Trace struct {
+		BranchesFrom string      `json:"branches_from,omitempty"`
+		SourceBranch string      `json:"source_branch"`
+		DestBranch   string      `json:"dest_branch"`
+		Strategy     string      `json:"strategy,omitempty"`
+		Decision     string      `json:"decision,omitempty"`
+		Pattern      string      `json:"pattern,omitempty"`
+		Method       string      `json:"method"`
+		Version      string      `json:"version,omitempty"`
+		LatestTag    string      `json:"latest_tag"`
+		AncestorTag  string      `json:"ancestor_tag,omitempty"`
+		Increments   []Increment `json:"increments"`
+		SemverTag    string      `json:"semver_tag"`
+		Notes        []string    `json:"notes,omitempty"`
+		// Components contains the trace of each monorepo component.
+		Components map[string]*Trace `json:"components,omitempty"`
+	}
+
+	// Increment contains a change applied to the version.
+	Increment struct {
+		Description string `json:"description"`
+		From        string `json:"from"`
+		To          string `json:"to"`
+	}
+)
+
+// Branches records the source and dest branches and where they were taken from.
+func (t *Trace) Branches(source, dest, from string) {
+	if t == nil {
+		return
+	}
+
+	t.SourceBranch = source
+	t.DestBranch = dest
+	t.BranchesFrom = from
+}
+
+// Decide records why the bump method was chosen and the pattern that matched, if any.
+func (t *Trace) Decide(decision string, pattern regex.Regex) {
+	if t == nil {
+		return
+	}
+
+	t.Decision = decision
+	t.Pattern = ""
+
+	if pattern != nil {
+		t.Pattern = pattern.String()
+	}
+}
+
+// Increment records a change applied to the version.
+func (t *Trace) Increment(description, from, to string) {
+	if t == nil {
+		return
+	}
+
+	t.Increments = append(t.Increments, Increment{
+		Description: description,
+		From:        from,
+		To:          to,
+	})
+}
+
+// Notef records a free text note.
+func (t *Trace) Notef(format string, args ...any) {
+	if t == nil {
+		return
+	}
+
+	t.Notes = append(t.Notes, fmt.Sprintf(format, args...))
+}
+
+// AddComponent records the trace of the monorepo component.
+func (t *Trace) AddComponent(name string, component *Trace) {
+	if t == nil || component == nil {
+		return
+	}
+
+	if t.Components == nil {
+		t.Components = make(map[string]*Trace)
+	}
+
+	t.Components[name] = component
+}
+
+// Text renders the trace as human readable text.
+func (t *Trace) Text() string {
+	if t == nil {
+		return ""
+	}
+
+	var b strings.Builder
+
+	t.write(&b, "")
+
+	return b.String()
+}
+
+func (t *Trace) write(b *strings.Builder, indent string) {
+	line := func(format string, args ...any) {
+		b.WriteString(indent)
+		fmt.Fprintf(b, format, args...)
+		b.WriteString("\n")
+	}
+
+	if t.SourceBranch != "" || t.DestBranch != "" {
+		from := ""
+		if t.BranchesFrom != "" {
+			from = " (from " + t.BranchesFrom + ")"
+		}
+
+		line("branches: %q -> %q%s", t.SourceBranch, t.DestBranch, from)
+	}
+
+	if t.Strategy != "" {
+		line("strategy: %s", t.Strategy)
+	}
+
+	if t.Decision != "" {
+		line("decision: %s", t.Decision)
+	}
+
+	if t.Pattern != "" {
+		line("pattern: %s", t.Pattern)
+	}
+
+	if t.Method != "" || t.Version != "" {
+		line("method: %q, version: %q", t.Method, t.Version)
+	}
+
+	if t.LatestTag != "" {
+		line("latest tag: %s", t.LatestTag)
+	}
+
+	for _, increment := range t.Increments {
+		line("%s: %s -> %s", increment.Description, increment.From, increment.To)
+	}
+
+	if t.AncestorTag != "" {
+		line("ancestor tag: %s", t.AncestorTag)
+	}
+
+	if t.SemverTag != "" {
+		line("semver tag: %s", t.SemverTag)
+	}
+
+	for _, note := range t.Notes {
+		line("note: %s", note)
+	}
+
+	names := make([]string, 0, len(t.Components))
+	for name := range t.Components {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		line("component %s:", name)
+		t.Components[name].write(b, indent+"  ")
+	}
+}
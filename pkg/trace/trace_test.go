@@ -0,0 +1,82 @@
+package trace_test
+
+import (
+	"testing"
+
+	"github.com/gandarez/semver-action/pkg/regex"
+	"github.com/gandarez/semver-action/pkg/trace"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrace_Nil(t *testing.T) {
+	var tr *trace.Trace
+
+	tr.Branches("feature/some", "develop", "push event payload")
+	tr.Decide("source branch matches minor pattern", regex.MustCompile(`^feature/.+`))
+	tr.Increment("increment minor", "1.2.3", "1.3.0")
+	tr.Notef("note %d", 1)
+	tr.AddComponent("api", &trace.Trace{})
+
+	assert.Nil(t, tr)
+	assert.Empty(t, tr.Text())
+}
+
+func TestTrace_Text(t *testing.T) {
+	tr := &trace.Trace{
+		Strategy:    "git-flow",
+		Method:      "build",
+		Version:     "minor",
+		LatestTag:   "v1.2.3-pre.4",
+		AncestorTag: "v1.2.2",
+		SemverTag:   "v1.3.0-pre.1",
+	}
+
+	tr.Branches("feature/some", "develop", "pull_request event payload")
+	tr.Decide("source branch matches minor pattern into develop branch", regex.MustCompile(`^feature/.+`))
+	tr.Increment("increment minor", "1.2.3-pre.4", "1.3.0-pre.4")
+	tr.Increment("set prerelease build number", "1.3.0-pre.4", "1.3.0-pre.1")
+	tr.Notef("dry run: tags not created")
+
+	expected := `branches: "feature/some" -> "develop" (from pull_request event payload)
+strategy: git-flow
+decision: source branch matches minor pattern into develop branch
+pattern: ^feature/.+
+method: "build", version: "minor"
+latest tag: v1.2.3-pre.4
+increment minor: 1.2.3-pre.4 -> 1.3.0-pre.4
+set prerelease build number: 1.3.0-pre.4 -> 1.3.0-pre.1
+ancestor tag: v1.2.2
+semver tag: v1.3.0-pre.1
+note: dry run: tags not created
+`
+
+	assert.Equal(t, expected, tr.Text())
+}
+
+func TestTrace_Text_Components(t *testing.T) {
+	tr := &trace.Trace{}
+
+	web := &trace.Trace{LatestTag: "web/v2.0.1"}
+	web.Notef("no changes in services/web/** since latest tag")
+
+	tr.AddComponent("web", web)
+	tr.AddComponent("api", &trace.Trace{
+		Strategy:  "trunk-based",
+		Method:    "patch",
+		LatestTag: "api/v1.4.0",
+		SemverTag: "api/v1.4.1",
+	})
+
+	expected := `component api:
+  strategy: trunk-based
+  method: "patch", version: ""
+  latest tag: api/v1.4.0
+  semver tag: api/v1.4.1
+component web:
+  latest tag: web/v2.0.1
+  note: no changes in services/web/** since latest tag
+`
+
+	assert.Equal(t, expected, tr.Text())
+}